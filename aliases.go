@@ -0,0 +1,129 @@
+package opensearchutil
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Alias is an index alias, to be added to an index with WithAliases or with an AddAlias action.
+// Refer to https://opensearch.org/docs/latest/im-plugin/index-alias/ for docs on each attribute.
+type Alias struct {
+	Name string
+
+	// Filter is a query that limits the documents the alias can access, e.g.
+	// map[string]interface{}{"term": map[string]interface{}{"tenant": "a"}}
+	Filter        map[string]interface{}
+	Routing       *string
+	IndexRouting  *string
+	SearchRouting *string
+	IsWriteIndex  *bool
+	IsHidden      *bool
+}
+
+// AliasAction is an action of the aliases API, made with AddAlias, RemoveAlias or RemoveIndex.
+type AliasAction struct {
+	kind  string
+	index string
+	alias Alias
+}
+
+type (
+	aliasDoc struct {
+		Filter        map[string]interface{} `json:"filter,omitempty"`
+		Routing       *string                `json:"routing,omitempty"`
+		IndexRouting  *string                `json:"index_routing,omitempty"`
+		SearchRouting *string                `json:"search_routing,omitempty"`
+		IsWriteIndex  *bool                  `json:"is_write_index,omitempty"`
+		IsHidden      *bool                  `json:"is_hidden,omitempty"`
+	}
+	aliasActionsDoc struct {
+		Actions []map[string]aliasActionDoc `json:"actions"`
+	}
+	aliasActionDoc struct {
+		Index string `json:"index"`
+		Alias string `json:"alias,omitempty"`
+		aliasDoc
+	}
+)
+
+// AddAlias makes an action that adds an alias to an index.
+func AddAlias(index string, alias Alias) AliasAction {
+	return AliasAction{kind: "add", index: index, alias: alias}
+}
+
+// RemoveAlias makes an action that removes an alias from an index.
+func RemoveAlias(index string, alias string) AliasAction {
+	return AliasAction{kind: "remove", index: index, alias: Alias{Name: alias}}
+}
+
+// RemoveIndex makes an action that deletes an index. Together with adding its alias to another index in the same
+// request, it swaps the index behind the alias atomically.
+func RemoveIndex(index string) AliasAction {
+	return AliasAction{kind: "remove_index", index: index}
+}
+
+// GenerateAliasActionsJson generates a JSON document for the aliases API. All actions are applied atomically, so
+// that e.g. moving an alias from a "blue" to a "green" index can be done with:
+//
+//	g.GenerateAliasActionsJson(RemoveAlias("people-blue", "people"), AddAlias("people-green", Alias{Name: "people"}))
+func (g *IndexGenerator) GenerateAliasActionsJson(actions ...AliasAction) ([]byte, error) {
+	if len(actions) == 0 {
+		return nil, ErrEmptyAliasActions
+	}
+
+	doc := aliasActionsDoc{Actions: make([]map[string]aliasActionDoc, 0, len(actions))}
+	for i, action := range actions {
+		if action.index == "" {
+			return nil, fmt.Errorf("alias action %d (%s): index is required", i, action.kind)
+		}
+		if action.kind != "remove_index" && action.alias.Name == "" {
+			return nil, errors.Wrapf(ErrEmptyAliasName, "alias action %d (%s)", i, action.kind)
+		}
+		doc.Actions = append(doc.Actions, map[string]aliasActionDoc{
+			action.kind: {
+				Index:    action.index,
+				Alias:    action.alias.Name,
+				aliasDoc: buildAliasDoc(action.alias),
+			},
+		})
+	}
+
+	jsonBytes, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "json.Marshal")
+	}
+
+	formattedJson, err := g.optionContainer.jsonFormatter.FormatJson(jsonBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "formatJson")
+	}
+	return formattedJson, nil
+}
+
+// buildAliases builds the "aliases" of an index or of a template, given with WithAliases.
+func buildAliases(aliases []Alias) (map[string]aliasDoc, error) {
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+	m := make(map[string]aliasDoc, len(aliases))
+	for i, a := range aliases {
+		if a.Name == "" {
+			return nil, errors.Wrapf(ErrEmptyAliasName, "alias %d", i)
+		}
+		m[a.Name] = buildAliasDoc(a)
+	}
+	return m, nil
+}
+
+func buildAliasDoc(alias Alias) aliasDoc {
+	return aliasDoc{
+		Filter:        alias.Filter,
+		Routing:       alias.Routing,
+		IndexRouting:  alias.IndexRouting,
+		SearchRouting: alias.SearchRouting,
+		IsWriteIndex:  alias.IsWriteIndex,
+		IsHidden:      alias.IsHidden,
+	}
+}
@@ -0,0 +1,118 @@
+package opensearchutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestIndexGenerator_GenerateIndexJson_addsAliases(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	resultJson, err := NewIndexGenerator().GenerateIndexJson(
+		[]MappingProperty{{FieldName: "tenant", FieldType: "keyword"}},
+		nil,
+		WithAliases(
+			Alias{Name: "people", IsWriteIndex: MakePtr(true)},
+			Alias{
+				Name: "people-tenant-a",
+				Filter: map[string]interface{}{
+					"term": map[string]interface{}{"tenant": "a"},
+				},
+				Routing:       MakePtr("a"),
+				IndexRouting:  MakePtr("a1"),
+				SearchRouting: MakePtr("a2"),
+				IsHidden:      MakePtr(true),
+			},
+		),
+	)
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "aliases": {
+      "people": {"is_write_index": true},
+      "people-tenant-a": {
+         "filter": {"term": {"tenant": "a"}},
+         "routing": "a",
+         "index_routing": "a1",
+         "search_routing": "a2",
+         "is_hidden": true
+      }
+   },
+   "mappings": {
+      "properties": {
+         "tenant": {"type": "keyword"}
+      }
+   }
+}`))
+}
+
+func TestIndexGenerator_GenerateIndexTemplateJson_addsAliases(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	resultJson, err := NewIndexGenerator().GenerateIndexTemplateJson(
+		IndexTemplate{IndexPatterns: []string{"people-*"}},
+		nil,
+		nil,
+		WithAliases(Alias{Name: "people"}),
+	)
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "index_patterns": ["people-*"],
+   "template": {
+      "aliases": {"people": {}}
+   }
+}`))
+}
+
+func TestIndexGenerator_addsAliases_ErrorsOnEmptyAliasName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	generator := NewIndexGenerator()
+	aliases := WithAliases(Alias{Name: "people"}, Alias{IsWriteIndex: MakePtr(true)})
+
+	_, err := generator.GenerateIndexJson(nil, nil, aliases)
+	g.Expect(errors.Is(err, ErrEmptyAliasName)).To(gomega.BeTrue())
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("alias 1: alias name is required")))
+
+	_, err = generator.GenerateIndexTemplateJson(IndexTemplate{IndexPatterns: []string{"people-*"}}, nil, nil, aliases)
+	g.Expect(errors.Is(err, ErrEmptyAliasName)).To(gomega.BeTrue())
+
+	_, err = generator.GenerateComponentTemplateJson(ComponentTemplate{}, nil, nil, aliases)
+	g.Expect(errors.Is(err, ErrEmptyAliasName)).To(gomega.BeTrue())
+}
+
+func TestIndexGenerator_GenerateAliasActionsJson(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	resultJson, err := NewIndexGenerator().GenerateAliasActionsJson(
+		AddAlias("people-green", Alias{Name: "people", IsWriteIndex: MakePtr(true)}),
+		RemoveAlias("people-blue", "people"),
+		RemoveIndex("people-old"),
+	)
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "actions": [
+      {"add": {"index": "people-green", "alias": "people", "is_write_index": true}},
+      {"remove": {"index": "people-blue", "alias": "people"}},
+      {"remove_index": {"index": "people-old"}}
+   ]
+}`))
+}
+
+func TestIndexGenerator_GenerateAliasActionsJson_ValidatesActions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	_, err := NewIndexGenerator().GenerateAliasActionsJson()
+	g.Expect(errors.Is(err, ErrEmptyAliasActions)).To(gomega.BeTrue())
+
+	_, err = NewIndexGenerator().GenerateAliasActionsJson(AddAlias("people", Alias{}))
+	g.Expect(errors.Is(err, ErrEmptyAliasName)).To(gomega.BeTrue())
+	g.Expect(err).To(gomega.MatchError("alias action 0 (add): alias name is required"))
+
+	_, err = NewIndexGenerator().GenerateAliasActionsJson(RemoveIndex(""))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("index is required")))
+}
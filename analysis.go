@@ -0,0 +1,209 @@
+package opensearchutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Analysis corresponds to settings.analysis of an index: custom analyzers and their building blocks, and normalizers.
+// Each map goes from a name, to be referenced from mapping properties or from other analysis components, to the
+// definition.
+// Refer to https://opensearch.org/docs/latest/analyzers/ for docs on each component.
+type Analysis struct {
+	Analyzer   map[string]Analyzer    `json:"analyzer,omitempty"`
+	Tokenizer  map[string]Tokenizer   `json:"tokenizer,omitempty"`
+	Filter     map[string]TokenFilter `json:"filter,omitempty"`
+	CharFilter map[string]CharFilter  `json:"char_filter,omitempty"`
+	Normalizer map[string]Normalizer  `json:"normalizer,omitempty"`
+}
+
+// Analyzer defines an analyzer. Type is "custom" for analyzers built from a tokenizer and filters, or the name of a
+// built-in analyzer to configure, such as "standard", "pattern" or "stop".
+type Analyzer struct {
+	Type string `json:"type"`
+
+	// custom
+	Tokenizer            string   `json:"tokenizer,omitempty"`
+	Filter               []string `json:"filter,omitempty"`
+	CharFilter           []string `json:"char_filter,omitempty"`
+	PositionIncrementGap *int     `json:"position_increment_gap,omitempty"`
+
+	// standard, stop, pattern and language analyzers
+	Stopwords      Stopwords `json:"stopwords,omitempty"`
+	StopwordsPath  *string   `json:"stopwords_path,omitempty"`
+	MaxTokenLength *int      `json:"max_token_length,omitempty"`
+
+	// pattern
+	Pattern   *string `json:"pattern,omitempty"`
+	Flags     *string `json:"flags,omitempty"`
+	Lowercase *bool   `json:"lowercase,omitempty"`
+}
+
+// Tokenizer defines a tokenizer, e.g. of type "standard", "pattern", "ngram", "edge_ngram" or "path_hierarchy".
+type Tokenizer struct {
+	Type string `json:"type"`
+
+	// standard, whitespace, classic
+	MaxTokenLength *int `json:"max_token_length,omitempty"`
+
+	// ngram, edge_ngram
+	MinGram          *int     `json:"min_gram,omitempty"`
+	MaxGram          *int     `json:"max_gram,omitempty"`
+	TokenChars       []string `json:"token_chars,omitempty"`
+	CustomTokenChars *string  `json:"custom_token_chars,omitempty"`
+
+	// pattern, simple_pattern, char_group
+	Pattern         *string  `json:"pattern,omitempty"`
+	Flags           *string  `json:"flags,omitempty"`
+	Group           *int     `json:"group,omitempty"`
+	TokenizeOnChars []string `json:"tokenize_on_chars,omitempty"`
+
+	// path_hierarchy
+	Delimiter   *string `json:"delimiter,omitempty"`
+	Replacement *string `json:"replacement,omitempty"`
+	Reverse     *bool   `json:"reverse,omitempty"`
+	Skip        *int    `json:"skip,omitempty"`
+}
+
+// TokenFilter defines a token filter, e.g. of type "synonym", "synonym_graph", "stemmer", "stop", "edge_ngram",
+// "shingle" or "pattern_replace".
+type TokenFilter struct {
+	Type string `json:"type"`
+
+	// synonym, synonym_graph
+	Synonyms     []string `json:"synonyms,omitempty"`
+	SynonymsPath *string  `json:"synonyms_path,omitempty"`
+	Expand       *bool    `json:"expand,omitempty"`
+	Lenient      *bool    `json:"lenient,omitempty"`
+
+	// stemmer, stop
+	Language      *string   `json:"language,omitempty"`
+	Stopwords     Stopwords `json:"stopwords,omitempty"`
+	StopwordsPath *string   `json:"stopwords_path,omitempty"`
+	IgnoreCase    *bool     `json:"ignore_case,omitempty"`
+
+	// ngram, edge_ngram, shingle
+	MinGram          *int  `json:"min_gram,omitempty"`
+	MaxGram          *int  `json:"max_gram,omitempty"`
+	PreserveOriginal *bool `json:"preserve_original,omitempty"`
+	MinShingleSize   *int  `json:"min_shingle_size,omitempty"`
+	MaxShingleSize   *int  `json:"max_shingle_size,omitempty"`
+
+	// pattern_replace, pattern_capture, length, truncate
+	Pattern     *string  `json:"pattern,omitempty"`
+	Patterns    []string `json:"patterns,omitempty"`
+	Replacement *string  `json:"replacement,omitempty"`
+	Min         *int     `json:"min,omitempty"`
+	Max         *int     `json:"max,omitempty"`
+	Length      *int     `json:"length,omitempty"`
+}
+
+// CharFilter defines a character filter of type "html_strip", "mapping" or "pattern_replace".
+type CharFilter struct {
+	Type string `json:"type"`
+
+	// html_strip
+	EscapedTags []string `json:"escaped_tags,omitempty"`
+
+	// mapping
+	Mappings     []string `json:"mappings,omitempty"`
+	MappingsPath *string  `json:"mappings_path,omitempty"`
+
+	// pattern_replace
+	Pattern     *string `json:"pattern,omitempty"`
+	Replacement *string `json:"replacement,omitempty"`
+	Flags       *string `json:"flags,omitempty"`
+}
+
+// Normalizer defines a normalizer for keyword fields. Type is "custom" and only filters that work on single
+// characters (such as "lowercase" or "asciifolding") are allowed.
+type Normalizer struct {
+	Type       string   `json:"type"`
+	Filter     []string `json:"filter,omitempty"`
+	CharFilter []string `json:"char_filter,omitempty"`
+}
+
+// Stopwords is a list of stop words, or a predefined list like "_english_" given as a single-element list.
+type Stopwords []string
+
+// MarshalJSON marshals a single predefined list name (like "_english_") as a string, as OpenSearch expects it.
+func (s Stopwords) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 && strings.HasPrefix(s[0], "_") && strings.HasSuffix(s[0], "_") {
+		return json.Marshal(s[0])
+	}
+	return json.Marshal([]string(s))
+}
+
+func (s *Stopwords) UnmarshalJSON(data []byte) error {
+	var list stringList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*s = Stopwords(list)
+	return nil
+}
+
+var (
+	// builtInAnalyzers are the analyzers that can be referenced without defining them in settings
+	builtInAnalyzers = map[string]bool{
+		"standard": true, "simple": true, "whitespace": true, "stop": true, "keyword": true, "pattern": true,
+		"fingerprint": true, "arabic": true, "armenian": true, "basque": true, "bengali": true, "brazilian": true,
+		"bulgarian": true, "catalan": true, "cjk": true, "czech": true, "danish": true, "dutch": true, "english": true,
+		"estonian": true, "finnish": true, "french": true, "galician": true, "german": true, "greek": true,
+		"hindi": true, "hungarian": true, "indonesian": true, "irish": true, "italian": true, "latvian": true,
+		"lithuanian": true, "norwegian": true, "persian": true, "portuguese": true, "romanian": true,
+		"russian": true, "sorani": true, "spanish": true, "swedish": true, "thai": true, "turkish": true,
+	}
+
+	// builtInNormalizers are the normalizers that can be referenced without defining them in settings
+	builtInNormalizers = map[string]bool{
+		"lowercase": true,
+	}
+)
+
+// ValidateAnalysisReferences checks that every analyzer, search_analyzer and normalizer referenced by the mapping
+// properties (including multi-fields and dynamic templates) is either built into OpenSearch or defined in
+// settings.Analysis. settings can be nil. All problems are reported in a single FieldErrors error.
+func ValidateAnalysisReferences(mappingProperties []MappingProperty, settings *IndexSettings) error {
+	var analysis Analysis
+	if settings != nil && settings.Analysis != nil {
+		analysis = *settings.Analysis
+	}
+
+	var errs FieldErrors
+	walkMappingProperties(mappingProperties, func(path string, mp MappingProperty) {
+		check := func(param string, name *string, builtIn map[string]bool, isDefined bool) {
+			if name == nil || builtIn[*name] || isDefined {
+				return
+			}
+			errs = append(errs, &FieldError{
+				Path:   path,
+				Reason: fmt.Sprintf("%s %q is neither built-in nor defined in the analysis settings", param, *name),
+			})
+		}
+		check(tagOptionAnalyzer, mp.Analyzer, builtInAnalyzers, isAnalyzerDefined(analysis, mp.Analyzer))
+		check(tagOptionSearchAnalyzer, mp.SearchAnalyzer, builtInAnalyzers, isAnalyzerDefined(analysis, mp.SearchAnalyzer))
+		check(tagOptionNormalizer, mp.Normalizer, builtInNormalizers, isNormalizerDefined(analysis, mp.Normalizer))
+	})
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func isAnalyzerDefined(analysis Analysis, name *string) bool {
+	if name == nil {
+		return false
+	}
+	_, ok := analysis.Analyzer[*name]
+	return ok
+}
+
+func isNormalizerDefined(analysis Analysis, name *string) bool {
+	if name == nil {
+		return false
+	}
+	_, ok := analysis.Normalizer[*name]
+	return ok
+}
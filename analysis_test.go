@@ -0,0 +1,150 @@
+package opensearchutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestIndexGenerator_GenerateIndexJson_addsAnalysisSettings(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	resultJson, err := NewIndexGenerator().GenerateIndexJson(
+		[]MappingProperty{{FieldName: "name", FieldType: "text", Analyzer: MakePtr("autocomplete")}},
+		&IndexSettings{
+			Analysis: &Analysis{
+				Analyzer: map[string]Analyzer{
+					"autocomplete": {
+						Type:       "custom",
+						Tokenizer:  "autocomplete_tokenizer",
+						Filter:     []string{"lowercase", "synonyms"},
+						CharFilter: []string{"strip_html"},
+					},
+					"english_stop": {Type: "standard", Stopwords: Stopwords{"_english_"}},
+					"custom_stop":  {Type: "stop", Stopwords: Stopwords{"a", "the"}},
+				},
+				Tokenizer: map[string]Tokenizer{
+					"autocomplete_tokenizer": {
+						Type:       "edge_ngram",
+						MinGram:    MakePtr(2),
+						MaxGram:    MakePtr(10),
+						TokenChars: []string{"letter", "digit"},
+					},
+				},
+				Filter: map[string]TokenFilter{
+					"synonyms":        {Type: "synonym", Synonyms: []string{"tv, television"}},
+					"english_stemmer": {Type: "stemmer", Language: MakePtr("light_english")},
+				},
+				CharFilter: map[string]CharFilter{
+					"strip_html": {Type: "html_strip"},
+				},
+				Normalizer: map[string]Normalizer{
+					"lowercase_ascii": {Type: "custom", Filter: []string{"lowercase", "asciifolding"}},
+				},
+			},
+		},
+	)
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "mappings": {
+      "properties": {
+         "name": {"type": "text", "analyzer": "autocomplete"}
+      }
+   },
+   "settings": {
+      "analysis": {
+         "analyzer": {
+            "autocomplete": {
+               "type": "custom",
+               "tokenizer": "autocomplete_tokenizer",
+               "filter": ["lowercase", "synonyms"],
+               "char_filter": ["strip_html"]
+            },
+            "english_stop": {"type": "standard", "stopwords": "_english_"},
+            "custom_stop": {"type": "stop", "stopwords": ["a", "the"]}
+         },
+         "tokenizer": {
+            "autocomplete_tokenizer": {
+               "type": "edge_ngram",
+               "min_gram": 2,
+               "max_gram": 10,
+               "token_chars": ["letter", "digit"]
+            }
+         },
+         "filter": {
+            "synonyms": {"type": "synonym", "synonyms": ["tv, television"]},
+            "english_stemmer": {"type": "stemmer", "language": "light_english"}
+         },
+         "char_filter": {
+            "strip_html": {"type": "html_strip"}
+         },
+         "normalizer": {
+            "lowercase_ascii": {"type": "custom", "filter": ["lowercase", "asciifolding"]}
+         }
+      }
+   }
+}`))
+}
+
+func TestValidateAnalysisReferences(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	settings := &IndexSettings{
+		Analysis: &Analysis{
+			Analyzer:   map[string]Analyzer{"autocomplete": {Type: "custom", Tokenizer: "standard"}},
+			Normalizer: map[string]Normalizer{"lowercase_ascii": {Type: "custom", Filter: []string{"lowercase"}}},
+		},
+	}
+
+	valid := []MappingProperty{
+		{FieldName: "name", FieldType: "text", Analyzer: MakePtr("autocomplete"), SearchAnalyzer: MakePtr("standard")},
+		{FieldName: "code", FieldType: "keyword", Normalizer: MakePtr("lowercase_ascii")},
+		{FieldName: "email", FieldType: "keyword", Normalizer: MakePtr("lowercase")},
+	}
+	g.Expect(ValidateAnalysisReferences(valid, settings)).To(gomega.Succeed())
+
+	invalid := []MappingProperty{
+		{
+			FieldName: "company",
+			Children: []MappingProperty{
+				{
+					FieldName: "name",
+					FieldType: "text",
+					Analyzer:  MakePtr("my_analyzer"),
+					Fields: []MappingProperty{
+						{FieldName: "raw", FieldType: "keyword", Normalizer: MakePtr("my_normalizer")},
+					},
+				},
+			},
+		},
+		{
+			FieldName:       "labels",
+			FieldType:       "object",
+			DynamicTemplate: &MappingProperty{FieldType: "text", SearchAnalyzer: MakePtr("autocomplete_search")},
+		},
+	}
+	err := ValidateAnalysisReferences(invalid, settings)
+	var fieldErrs FieldErrors
+	g.Expect(errors.As(err, &fieldErrs)).To(gomega.BeTrue())
+	g.Expect(fieldErrs).To(gomega.Equal(FieldErrors{
+		{Path: "company.name", Reason: `analyzer "my_analyzer" is neither built-in nor defined in the analysis settings`},
+		{
+			Path:   "company.name.raw",
+			Reason: `normalizer "my_normalizer" is neither built-in nor defined in the analysis settings`,
+		},
+		{
+			Path:   "labels.*",
+			Reason: `search_analyzer "autocomplete_search" is neither built-in nor defined in the analysis settings`,
+		},
+	}))
+
+	var fieldErr *FieldError
+	g.Expect(errors.As(err, &fieldErr)).To(gomega.BeTrue())
+	g.Expect(fieldErr).To(gomega.BeIdenticalTo(fieldErrs[0]))
+	g.Expect(errors.Is(err, fieldErrs[2])).To(gomega.BeTrue())
+
+	err = ValidateAnalysisReferences(invalid, nil)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("company.name: analyzer")))
+}
@@ -0,0 +1,312 @@
+package opensearchutil
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// BulkActionType is the type of an action of the _bulk API.
+type BulkActionType string
+
+const (
+	// BulkIndex creates a document or replaces it if it exists.
+	BulkIndex BulkActionType = "index"
+
+	// BulkCreate creates a document, failing if it exists.
+	BulkCreate BulkActionType = "create"
+
+	// BulkUpdate updates a document partially with the fields of BulkAction.Document.
+	BulkUpdate BulkActionType = "update"
+
+	// BulkDelete deletes a document.
+	BulkDelete BulkActionType = "delete"
+)
+
+// BulkAction is a single action of a _bulk request.
+// Refer to https://opensearch.org/docs/latest/api-reference/document-apis/bulk/ for docs on each attribute.
+type BulkAction struct {
+	Type BulkActionType
+
+	// Index can be left empty if the _bulk request is sent to an index, e.g. "POST /products/_bulk"
+	Index string
+
+	// ID is the _id of the document. If it is empty and Document is a struct with a field tagged `opensearch:"id"`, the
+	// ID is the value of that field. Without an ID, OpenSearch generates one for index and create actions. Update and
+	// delete actions require an ID.
+	ID string
+
+	Routing       string
+	Version       *int64
+	VersionType   string
+	IfSeqNo       *int64
+	IfPrimaryTerm *int64
+	Pipeline      string
+
+	// RetryOnConflict applies to update actions
+	RetryOnConflict *int
+
+	// DocAsUpsert makes an update action create the document from Document if it does not exist
+	DocAsUpsert bool
+
+	// Document is marshalled with encoding/json as the source of index and create actions, and as the partial
+	// document of update actions. It must be nil for delete actions.
+	Document interface{}
+}
+
+type (
+	bulkActionDoc struct {
+		Index           string `json:"_index,omitempty"`
+		ID              string `json:"_id,omitempty"`
+		Routing         string `json:"routing,omitempty"`
+		Version         *int64 `json:"version,omitempty"`
+		VersionType     string `json:"version_type,omitempty"`
+		IfSeqNo         *int64 `json:"if_seq_no,omitempty"`
+		IfPrimaryTerm   *int64 `json:"if_primary_term,omitempty"`
+		Pipeline        string `json:"pipeline,omitempty"`
+		RetryOnConflict *int   `json:"retry_on_conflict,omitempty"`
+	}
+	bulkUpdateDoc struct {
+		Doc         interface{} `json:"doc"`
+		DocAsUpsert bool        `json:"doc_as_upsert,omitempty"`
+	}
+)
+
+// BulkBatchWriter is a writer that gets the actions of each batch together with its body, e.g. to send the batch as a
+// _bulk request and to parse the response with ParseBulkResponse. As with io.Writer, body is only valid during the
+// call: BulkEncoder reuses it for the next batch, so a writer that keeps it (e.g. to send it asynchronously or to retry
+// it later) must copy it.
+type BulkBatchWriter interface {
+	WriteBatch(body []byte, actions []BulkAction) error
+}
+
+// BulkEncoder writes actions as the NDJSON body of _bulk requests: an action line, followed by a source line for
+// index, create and update actions. It buffers actions and writes them to the underlying writer in batches, each with
+// a single Write call, so that a writer can send every batch as a separate _bulk request. If the writer is also a
+// BulkBatchWriter, batches are written with WriteBatch instead.
+type BulkEncoder struct {
+	w               io.Writer
+	optionContainer bulkEncoderOptionContainer
+	buf             bytes.Buffer
+	batch           []BulkAction
+}
+
+// NewBulkEncoder creates a BulkEncoder that writes to w. Without flush options, actions are only written on Flush.
+func NewBulkEncoder(w io.Writer, options ...BulkEncoderOption) *BulkEncoder {
+	optContainer := bulkEncoderOptionContainer{}
+	for _, o := range options {
+		o.apply(&optContainer)
+	}
+	return &BulkEncoder{w: w, optionContainer: optContainer}
+}
+
+// Encode adds an action to the current batch. It flushes the batch first if the action would make it larger than the
+// flush size, and after adding the action if the batch reaches the flush count.
+func (e *BulkEncoder) Encode(action BulkAction) error {
+	entry, err := encodeBulkAction(action)
+	if err != nil {
+		return errors.Wrapf(err, "encodeBulkAction")
+	}
+
+	maxBytes := e.optionContainer.flushBytes
+	if maxBytes > 0 && e.buf.Len() > 0 && e.buf.Len()+len(entry) > maxBytes {
+		if err := e.Flush(); err != nil {
+			return err
+		}
+	}
+
+	e.buf.Write(entry)
+	e.batch = append(e.batch, action)
+
+	if maxActions := e.optionContainer.flushActions; maxActions > 0 && len(e.batch) >= maxActions {
+		return e.Flush()
+	}
+	return nil
+}
+
+// Flush writes the current batch, if any, to the underlying writer.
+func (e *BulkEncoder) Flush() error {
+	if e.buf.Len() == 0 {
+		return nil
+	}
+	if batchWriter, ok := e.w.(BulkBatchWriter); ok {
+		if err := batchWriter.WriteBatch(e.buf.Bytes(), e.batch); err != nil {
+			return errors.Wrapf(err, "WriteBatch")
+		}
+	} else if _, err := e.w.Write(e.buf.Bytes()); err != nil {
+		return errors.Wrapf(err, "Write")
+	}
+	e.buf.Reset()
+	e.batch = nil
+	return nil
+}
+
+// Buffered returns the number of actions in the current batch, which have not been written yet.
+func (e *BulkEncoder) Buffered() int {
+	return len(e.batch)
+}
+
+// encodeBulkAction encodes the action line and the source line of an action, each ending with a newline.
+func encodeBulkAction(action BulkAction) ([]byte, error) {
+	switch action.Type {
+	case BulkIndex, BulkCreate, BulkUpdate:
+		if action.Document == nil {
+			return nil, fmt.Errorf("%s action requires a document", action.Type)
+		}
+	case BulkDelete:
+		if action.Document != nil {
+			return nil, fmt.Errorf("%s action cannot have a document", action.Type)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported bulk action type %q", action.Type)
+	}
+
+	id := action.ID
+	if id == "" && action.Document != nil {
+		var err error
+		if id, err = documentID(action.Document); err != nil {
+			return nil, errors.Wrapf(err, "documentID")
+		}
+	}
+	if (action.Type == BulkUpdate || action.Type == BulkDelete) && id == "" {
+		return nil, fmt.Errorf("%s action requires an ID", action.Type)
+	}
+
+	actionLine, err := json.Marshal(map[BulkActionType]bulkActionDoc{
+		action.Type: {
+			Index:           action.Index,
+			ID:              id,
+			Routing:         action.Routing,
+			Version:         action.Version,
+			VersionType:     action.VersionType,
+			IfSeqNo:         action.IfSeqNo,
+			IfPrimaryTerm:   action.IfPrimaryTerm,
+			Pipeline:        action.Pipeline,
+			RetryOnConflict: action.RetryOnConflict,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "json.Marshal")
+	}
+
+	entry := append(actionLine, '\n')
+	if action.Document == nil {
+		return entry, nil
+	}
+
+	var source interface{} = action.Document
+	if action.Type == BulkUpdate {
+		source = bulkUpdateDoc{Doc: action.Document, DocAsUpsert: action.DocAsUpsert}
+	}
+	sourceLine, err := json.Marshal(source)
+	if err != nil {
+		return nil, errors.Wrapf(err, "json.Marshal")
+	}
+	return append(append(entry, sourceLine...), '\n'), nil
+}
+
+// idFieldIndexes caches the idField per struct type.
+var idFieldIndexes sync.Map
+
+// idField is the index of the field tagged `opensearch:"id"` of a struct type, nil if it has none, or the error of
+// looking it up.
+type idField struct {
+	index []int
+	err   error
+}
+
+// documentID returns the value of the field tagged `opensearch:"id"` of a struct document, or "" if the document is
+// not a struct or has no such field.
+func documentID(document interface{}) (string, error) {
+	v := reflect.ValueOf(document)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", nil
+	}
+
+	cached, ok := idFieldIndexes.Load(v.Type())
+	if !ok {
+		index, err := findIdFieldIndex(v.Type())
+		cached = idField{index: index, err: err}
+		idFieldIndexes.Store(v.Type(), cached)
+	}
+	idf := cached.(idField)
+	if idf.err != nil {
+		return "", idf.err
+	}
+	if idf.index == nil {
+		return "", nil
+	}
+
+	field, err := v.FieldByIndexErr(idf.index)
+	if err != nil {
+		return "", nil // The ID field is promoted from a nil embedded pointer
+	}
+	return formatDocumentID(field)
+}
+
+// findIdFieldIndex returns the index of the exported field tagged `opensearch:"id"`, which can be promoted from an
+// embedded struct. More than one such field is an error, since it is ambiguous which one holds the ID.
+func findIdFieldIndex(t reflect.Type) ([]int, error) {
+	var index []int
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || !parseFieldTags(field).id {
+			continue
+		}
+		if index != nil {
+			return nil, fmt.Errorf("%s has more than one field tagged opensearch:\"id\"", t)
+		}
+		index = field.Index
+	}
+	return index, nil
+}
+
+func formatDocumentID(v reflect.Value) (string, error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+	if !v.CanInterface() {
+		// Promoted from an unexported embedded struct, only the kind can be used
+		return formatDocumentIDKind(v)
+	}
+	if marshaler, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(err, "MarshalText")
+		}
+		return string(text), nil
+	}
+	if v.Kind() == reflect.Struct {
+		if stringer, ok := v.Interface().(fmt.Stringer); ok {
+			return stringer.String(), nil
+		}
+	}
+	return formatDocumentIDKind(v)
+}
+
+func formatDocumentIDKind(v reflect.Value) (string, error) {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported ID field type %s", v.Type())
+}
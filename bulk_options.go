@@ -0,0 +1,41 @@
+package opensearchutil
+
+type BulkEncoderOption interface {
+	apply(*bulkEncoderOptionContainer)
+}
+
+type bulkEncoderOptionContainer struct {
+	flushActions int
+	flushBytes   int
+}
+
+type flushActionsOption struct {
+	actions int
+}
+
+func (c flushActionsOption) apply(opts *bulkEncoderOptionContainer) {
+	opts.flushActions = c.actions
+}
+
+// WithFlushActions makes BulkEncoder write a batch when it has the given number of actions.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithFlushActions(actions int) BulkEncoderOption {
+	return flushActionsOption{actions: actions}
+}
+
+type flushBytesOption struct {
+	bytes int
+}
+
+func (c flushBytesOption) apply(opts *bulkEncoderOptionContainer) {
+	opts.flushBytes = c.bytes
+}
+
+// WithFlushBytes makes BulkEncoder write a batch before it grows larger than the given number of bytes. A single action
+// larger than that is written in a batch of its own.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithFlushBytes(bytes int) BulkEncoderOption {
+	return flushBytesOption{bytes: bytes}
+}
@@ -0,0 +1,178 @@
+package opensearchutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// BulkFailureKind classifies a failed item of a _bulk response.
+type BulkFailureKind string
+
+const (
+	// BulkFailureVersionConflict is a failed create action of an existing document, or an action whose version,
+	// if_seq_no or if_primary_term does not match the document.
+	BulkFailureVersionConflict BulkFailureKind = "version_conflict"
+
+	// BulkFailureMapperParsing is a document that does not match the mapping of the index.
+	BulkFailureMapperParsing BulkFailureKind = "mapper_parsing"
+
+	// BulkFailureRejected is an action rejected because the cluster is overloaded (status 429). It can be retried.
+	BulkFailureRejected BulkFailureKind = "rejected"
+
+	// BulkFailureDocumentMissing is an update action of a document that does not exist.
+	BulkFailureDocumentMissing BulkFailureKind = "document_missing"
+
+	// BulkFailureOther is any other failure. It can be retried if its status is 500 or above.
+	BulkFailureOther BulkFailureKind = "other"
+)
+
+// BulkResponse is a parsed _bulk response, with every item matched with the action of the request it reports on.
+type BulkResponse struct {
+	Took   int64
+	Errors bool
+	Items  []BulkItem
+}
+
+// BulkItem is the result of a single action of a _bulk request.
+type BulkItem struct {
+	// Action is the action of the request that the item reports on
+	Action BulkAction
+
+	Index       string
+	ID          string
+	Version     *int64
+	SeqNo       *int64
+	PrimaryTerm *int64
+
+	// Result is e.g. "created", "updated", "deleted" or "not_found" for successful actions
+	Result string
+	Status int
+
+	// Error is nil for successful actions
+	Error *BulkItemError
+}
+
+// BulkItemError is the error of a failed item.
+type BulkItemError struct {
+	Kind   BulkFailureKind
+	Type   string
+	Reason string
+}
+
+func (e *BulkItemError) Error() string {
+	return e.Type + ": " + e.Reason
+}
+
+type (
+	bulkResponseDoc struct {
+		Took   int64                                 `json:"took"`
+		Errors bool                                  `json:"errors"`
+		Items  []map[BulkActionType]bulkResponseItem `json:"items"`
+	}
+	bulkResponseItem struct {
+		Index       string `json:"_index"`
+		ID          string `json:"_id"`
+		Version     *int64 `json:"_version"`
+		SeqNo       *int64 `json:"_seq_no"`
+		PrimaryTerm *int64 `json:"_primary_term"`
+		Result      string `json:"result"`
+		Status      int    `json:"status"`
+		Error       *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+)
+
+// ParseBulkResponse parses the response of a _bulk request made of actions, in the order they were encoded.
+func ParseBulkResponse(body []byte, actions []BulkAction) (*BulkResponse, error) {
+	var doc bulkResponseDoc
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, errors.Wrapf(err, "json.Unmarshal")
+	}
+	if len(doc.Items) != len(actions) {
+		return nil, fmt.Errorf("the response has %d items for %d actions", len(doc.Items), len(actions))
+	}
+
+	resp := &BulkResponse{Took: doc.Took, Errors: doc.Errors, Items: make([]BulkItem, 0, len(doc.Items))}
+	for i, rawItem := range doc.Items {
+		raw, ok := rawItem[actions[i].Type]
+		if !ok || len(rawItem) != 1 {
+			return nil, fmt.Errorf("item %d does not report on a %s action", i, actions[i].Type)
+		}
+
+		item := BulkItem{
+			Action:      actions[i],
+			Index:       raw.Index,
+			ID:          raw.ID,
+			Version:     raw.Version,
+			SeqNo:       raw.SeqNo,
+			PrimaryTerm: raw.PrimaryTerm,
+			Result:      raw.Result,
+			Status:      raw.Status,
+		}
+		if raw.Error != nil {
+			item.Error = &BulkItemError{
+				Kind:   classifyBulkFailure(raw.Status, raw.Error.Type),
+				Type:   raw.Error.Type,
+				Reason: raw.Error.Reason,
+			}
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp, nil
+}
+
+func classifyBulkFailure(status int, errorType string) BulkFailureKind {
+	switch {
+	case errorType == "version_conflict_engine_exception" || status == http.StatusConflict:
+		return BulkFailureVersionConflict
+	case errorType == "mapper_parsing_exception":
+		return BulkFailureMapperParsing
+	case status == http.StatusTooManyRequests:
+		return BulkFailureRejected
+	case errorType == "document_missing_exception":
+		return BulkFailureDocumentMissing
+	default:
+		return BulkFailureOther
+	}
+}
+
+// Failed tells whether the action of the item failed.
+func (i BulkItem) Failed() bool {
+	return i.Error != nil
+}
+
+// Retryable tells whether the action of the item failed for a temporary reason and can be sent again: it was
+// rejected, or failed with a status of 500 or above.
+func (i BulkItem) Retryable() bool {
+	if i.Error == nil {
+		return false
+	}
+	return i.Error.Kind == BulkFailureRejected || i.Status >= http.StatusInternalServerError
+}
+
+// FailedItems returns the items of the failed actions.
+func (r *BulkResponse) FailedItems() []BulkItem {
+	var items []BulkItem
+	for _, item := range r.Items {
+		if item.Failed() {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// RetryActions returns the actions of the retryable items, in order, to be encoded into a new _bulk request.
+func (r *BulkResponse) RetryActions() []BulkAction {
+	var actions []BulkAction
+	for _, item := range r.Items {
+		if item.Retryable() {
+			actions = append(actions, item.Action)
+		}
+	}
+	return actions
+}
@@ -0,0 +1,90 @@
+package opensearchutil
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestParseBulkResponse(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	actions := []BulkAction{
+		{Type: BulkIndex, Index: "products", Document: bulkTestDoc{ID: "1"}},
+		{Type: BulkCreate, Index: "products", Document: bulkTestDoc{ID: "2"}},
+		{Type: BulkIndex, Index: "products", Document: bulkTestDoc{ID: "3"}},
+		{Type: BulkIndex, Index: "products", Document: bulkTestDoc{ID: "4"}},
+		{Type: BulkUpdate, Index: "products", Document: bulkTestDoc{ID: "5"}},
+		{Type: BulkDelete, Index: "products", ID: "6"},
+		{Type: BulkIndex, Index: "products", Document: bulkTestDoc{ID: "7"}},
+	}
+	body := `{
+		"took": 30,
+		"errors": true,
+		"items": [
+			{"index": {"_index": "products", "_id": "1", "_version": 1, "result": "created", "status": 201,
+				"_seq_no": 0, "_primary_term": 1}},
+			{"create": {"_index": "products", "_id": "2", "status": 409, "error": {
+				"type": "version_conflict_engine_exception",
+				"reason": "[2]: version conflict, document already exists (current version [1])"}}},
+			{"index": {"_index": "products", "_id": "3", "status": 400, "error": {
+				"type": "mapper_parsing_exception", "reason": "failed to parse field [views] of type [long]"}}},
+			{"index": {"_index": "products", "_id": "4", "status": 429, "error": {
+				"type": "rejected_execution_exception", "reason": "rejected execution of coordinating operation"}}},
+			{"update": {"_index": "products", "_id": "5", "status": 404, "error": {
+				"type": "document_missing_exception", "reason": "[5]: document missing"}}},
+			{"delete": {"_index": "products", "_id": "6", "_version": 1, "result": "not_found", "status": 404}},
+			{"index": {"_index": "products", "_id": "7", "status": 503, "error": {
+				"type": "unavailable_shards_exception", "reason": "primary shard is not active"}}}
+		]
+	}`
+
+	resp, err := ParseBulkResponse([]byte(body), actions)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(resp.Took).To(gomega.Equal(int64(30)))
+	g.Expect(resp.Errors).To(gomega.BeTrue())
+	g.Expect(resp.Items).To(gomega.HaveLen(7))
+	g.Expect(resp.Items[0]).To(gomega.Equal(BulkItem{
+		Action:      actions[0],
+		Index:       "products",
+		ID:          "1",
+		Version:     MakePtr(int64(1)),
+		SeqNo:       MakePtr(int64(0)),
+		PrimaryTerm: MakePtr(int64(1)),
+		Result:      "created",
+		Status:      201,
+	}))
+	g.Expect(resp.Items[5].Failed()).To(gomega.BeFalse())
+	g.Expect(resp.Items[5].Result).To(gomega.Equal("not_found"))
+
+	var kinds []BulkFailureKind
+	for _, item := range resp.FailedItems() {
+		kinds = append(kinds, item.Error.Kind)
+	}
+	g.Expect(kinds).To(gomega.Equal([]BulkFailureKind{
+		BulkFailureVersionConflict,
+		BulkFailureMapperParsing,
+		BulkFailureRejected,
+		BulkFailureDocumentMissing,
+		BulkFailureOther,
+	}))
+	g.Expect(resp.Items[2].Error).To(gomega.MatchError(
+		"mapper_parsing_exception: failed to parse field [views] of type [long]"))
+
+	g.Expect(resp.RetryActions()).To(gomega.Equal([]BulkAction{actions[3], actions[6]}))
+}
+
+func TestParseBulkResponse_Mismatch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	body := `{"took": 1, "errors": false, "items": [{"index": {"_id": "1", "status": 201}}]}`
+
+	_, err := ParseBulkResponse([]byte(body), nil)
+	g.Expect(err).To(gomega.MatchError("the response has 1 items for 0 actions"))
+
+	_, err = ParseBulkResponse([]byte(body), []BulkAction{{Type: BulkDelete, ID: "1"}})
+	g.Expect(err).To(gomega.MatchError("item 0 does not report on a delete action"))
+
+	_, err = ParseBulkResponse([]byte(`<html>`), nil)
+	g.Expect(err).NotTo(gomega.BeNil())
+}
@@ -0,0 +1,226 @@
+package opensearchutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+type bulkTestDoc struct {
+	ID    string `json:"id" opensearch:"type:keyword,id"`
+	Title string `json:"title"`
+}
+
+// bulkBatchRecorder records every Write as a batch.
+type bulkBatchRecorder struct {
+	batches []string
+}
+
+func (r *bulkBatchRecorder) Write(p []byte) (int, error) {
+	r.batches = append(r.batches, string(p))
+	return len(p), nil
+}
+
+func TestBulkEncoder_Encode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var buf bytes.Buffer
+	encoder := NewBulkEncoder(&buf)
+	for _, action := range []BulkAction{
+		{Type: BulkIndex, Index: "products", Document: bulkTestDoc{ID: "1", Title: "a"}},
+		{
+			Type:          BulkCreate,
+			ID:            "explicit",
+			Routing:       "tenant-a",
+			Pipeline:      "enrich",
+			IfSeqNo:       MakePtr(int64(5)),
+			IfPrimaryTerm: MakePtr(int64(1)),
+			Document:      &bulkTestDoc{ID: "2", Title: "b"},
+		},
+		{
+			Type:        BulkIndex,
+			Version:     MakePtr(int64(7)),
+			VersionType: "external",
+			Document:    map[string]interface{}{"title": "no id"},
+		},
+		{
+			Type:            BulkUpdate,
+			RetryOnConflict: MakePtr(3),
+			DocAsUpsert:     true,
+			Document:        bulkTestDoc{ID: "3", Title: "c"},
+		},
+		{Type: BulkDelete, Index: "products", ID: "4"},
+	} {
+		g.Expect(encoder.Encode(action)).To(gomega.Succeed())
+	}
+	g.Expect(buf.Len()).To(gomega.BeZero())
+	g.Expect(encoder.Buffered()).To(gomega.Equal(5))
+
+	g.Expect(encoder.Flush()).To(gomega.Succeed())
+	g.Expect(encoder.Buffered()).To(gomega.BeZero())
+	g.Expect(buf.String()).To(gomega.Equal(`{"index":{"_index":"products","_id":"1"}}
+{"id":"1","title":"a"}
+{"create":{"_id":"explicit","routing":"tenant-a","if_seq_no":5,"if_primary_term":1,"pipeline":"enrich"}}
+{"id":"2","title":"b"}
+{"index":{"version":7,"version_type":"external"}}
+{"title":"no id"}
+{"update":{"_id":"3","retry_on_conflict":3}}
+{"doc":{"id":"3","title":"c"},"doc_as_upsert":true}
+{"delete":{"_index":"products","_id":"4"}}
+`))
+}
+
+func TestBulkEncoder_FlushActions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	recorder := &bulkBatchRecorder{}
+	encoder := NewBulkEncoder(recorder, WithFlushActions(2))
+	for _, id := range []string{"1", "2", "3"} {
+		g.Expect(encoder.Encode(BulkAction{Type: BulkDelete, ID: id})).To(gomega.Succeed())
+	}
+	g.Expect(encoder.Flush()).To(gomega.Succeed())
+	g.Expect(encoder.Flush()).To(gomega.Succeed())
+
+	g.Expect(recorder.batches).To(gomega.Equal([]string{
+		"{\"delete\":{\"_id\":\"1\"}}\n{\"delete\":{\"_id\":\"2\"}}\n",
+		"{\"delete\":{\"_id\":\"3\"}}\n",
+	}))
+}
+
+func TestBulkEncoder_FlushBytes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// Each delete action of a single-digit ID takes 24 bytes
+	recorder := &bulkBatchRecorder{}
+	encoder := NewBulkEncoder(recorder, WithFlushBytes(50))
+	for _, id := range []string{"1", "2", "3"} {
+		g.Expect(encoder.Encode(BulkAction{Type: BulkDelete, ID: id})).To(gomega.Succeed())
+	}
+	g.Expect(encoder.Encode(BulkAction{Type: BulkIndex, Document: bulkTestDoc{ID: "4", Title: "a long title"}})).
+		To(gomega.Succeed())
+	g.Expect(encoder.Flush()).To(gomega.Succeed())
+
+	g.Expect(recorder.batches).To(gomega.Equal([]string{
+		"{\"delete\":{\"_id\":\"1\"}}\n{\"delete\":{\"_id\":\"2\"}}\n",
+		"{\"delete\":{\"_id\":\"3\"}}\n",
+		"{\"index\":{\"_id\":\"4\"}}\n{\"id\":\"4\",\"title\":\"a long title\"}\n",
+	}))
+}
+
+func TestBulkEncoder_Encode_Invalid(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	encoder := NewBulkEncoder(&bytes.Buffer{})
+	g.Expect(encoder.Encode(BulkAction{Type: BulkIndex})).
+		To(gomega.MatchError("encodeBulkAction: index action requires a document"))
+	g.Expect(encoder.Encode(BulkAction{Type: BulkDelete, ID: "1", Document: bulkTestDoc{}})).
+		To(gomega.MatchError("encodeBulkAction: delete action cannot have a document"))
+	g.Expect(encoder.Encode(BulkAction{Type: BulkUpdate, Document: map[string]string{}})).
+		To(gomega.MatchError("encodeBulkAction: update action requires an ID"))
+	g.Expect(encoder.Encode(BulkAction{Type: BulkDelete, Index: "products"})).
+		To(gomega.MatchError("encodeBulkAction: delete action requires an ID"))
+	g.Expect(encoder.Encode(BulkAction{Type: "upsert"})).
+		To(gomega.MatchError(`encodeBulkAction: unsupported bulk action type "upsert"`))
+	g.Expect(encoder.Buffered()).To(gomega.BeZero())
+}
+
+type bulkTestNumber int64
+
+func (n bulkTestNumber) MarshalText() ([]byte, error) {
+	return []byte("n-" + string(rune('0'+n))), nil
+}
+
+func Test_documentID(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type base struct {
+		Key uint32 `opensearch:"id"`
+	}
+	type embedding struct {
+		base
+		Title string
+	}
+	type embeddingPtr struct {
+		*base
+	}
+
+	tests := []struct {
+		name     string
+		document interface{}
+		expected string
+	}{
+		{name: "string", document: bulkTestDoc{ID: "x"}, expected: "x"},
+		{name: "int", document: struct {
+			ID int64 `opensearch:"id"`
+		}{ID: -5}, expected: "-5"},
+		{name: "pointer", document: &struct {
+			ID *string `opensearch:"id"`
+		}{ID: MakePtr("p")}, expected: "p"},
+		{name: "nil pointer", document: struct {
+			ID *string `opensearch:"id"`
+		}{}, expected: ""},
+		{name: "text marshaler", document: struct {
+			ID bulkTestNumber `opensearch:"id"`
+		}{ID: 7}, expected: "n-7"},
+		{name: "promoted", document: embedding{base: base{Key: 9}}, expected: "9"},
+		{name: "nil embedded pointer", document: embeddingPtr{}, expected: ""},
+		{name: "no id field", document: struct{ ID string }{ID: "x"}, expected: ""},
+		{name: "map", document: map[string]string{"id": "x"}, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := documentID(tt.document)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(id).To(gomega.Equal(tt.expected))
+		})
+	}
+
+	_, err := documentID(struct {
+		ID float64 `opensearch:"id"`
+	}{})
+	g.Expect(err).To(gomega.MatchError("unsupported ID field type float64"))
+
+	type twoIDs struct {
+		base
+		ID string `opensearch:"id"`
+	}
+	for i := 0; i < 2; i++ { // The second time from the cache
+		_, err = documentID(twoIDs{ID: "x"})
+		g.Expect(err).To(gomega.MatchError(
+			gomega.ContainSubstring(`twoIDs has more than one field tagged opensearch:"id"`)))
+	}
+	err = NewBulkEncoder(&bytes.Buffer{}).Encode(BulkAction{Type: BulkIndex, Document: twoIDs{ID: "x"}})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("documentID")))
+}
+
+// bulkTestBatchWriter is a BulkBatchWriter that records the actions of every batch.
+type bulkTestBatchWriter struct {
+	bulkBatchRecorder
+	actions [][]BulkAction
+}
+
+func (w *bulkTestBatchWriter) WriteBatch(body []byte, actions []BulkAction) error {
+	w.batches = append(w.batches, string(body))
+	w.actions = append(w.actions, actions)
+	return nil
+}
+
+func TestBulkEncoder_BulkBatchWriter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	writer := &bulkTestBatchWriter{}
+	encoder := NewBulkEncoder(writer, WithFlushActions(2))
+	actions := []BulkAction{
+		{Type: BulkDelete, ID: "1"},
+		{Type: BulkDelete, ID: "2"},
+		{Type: BulkDelete, ID: "3"},
+	}
+	for _, action := range actions {
+		g.Expect(encoder.Encode(action)).To(gomega.Succeed())
+	}
+	g.Expect(encoder.Flush()).To(gomega.Succeed())
+
+	g.Expect(writer.batches).To(gomega.HaveLen(2))
+	g.Expect(writer.actions).To(gomega.Equal([][]BulkAction{actions[:2], actions[2:]}))
+}
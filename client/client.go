@@ -0,0 +1,156 @@
+// Package client is a minimal OpenSearch REST client for managing indices with the documents generated by the
+// opensearchutil package: creating and deleting indices, putting and getting mappings, and putting templates.
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Client sends requests to an OpenSearch cluster. It is safe for concurrent use.
+type Client struct {
+	baseURL         *url.URL
+	optionContainer optionContainer
+}
+
+// New creates a Client for the cluster at baseURL, e.g. "https://localhost:9200".
+func New(baseURL string, options ...Option) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "url.Parse")
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, errors.Errorf("base URL %q must have a scheme and a host", baseURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+
+	optContainer := optionContainer{header: make(http.Header)}
+	for _, o := range options {
+		o.apply(&optContainer)
+	}
+	if optContainer.httpClient == nil {
+		optContainer.httpClient = http.DefaultClient
+	}
+
+	return &Client{baseURL: u, optionContainer: optContainer}, nil
+}
+
+// CreateIndex creates an index with a document of IndexGenerator.GenerateIndexJson.
+func (c *Client) CreateIndex(ctx context.Context, index string, body []byte) error {
+	_, err := c.do(ctx, http.MethodPut, []string{index}, body)
+	return err
+}
+
+// DeleteIndex deletes an index. It returns an *Error for which IsNotFound is true if the index does not exist.
+func (c *Client) DeleteIndex(ctx context.Context, index string) error {
+	_, err := c.do(ctx, http.MethodDelete, []string{index}, nil)
+	return err
+}
+
+// IndexExists tells whether an index (or an alias) exists.
+func (c *Client) IndexExists(ctx context.Context, index string) (bool, error) {
+	_, err := c.do(ctx, http.MethodHead, []string{index}, nil)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetIndex returns the aliases, mappings and settings of an index, keyed by the index name, as OpenSearch returns
+// them. It can be parsed with MappingParser.ParseIndicesJson.
+func (c *Client) GetIndex(ctx context.Context, index string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, []string{index}, nil)
+}
+
+// PutSettings updates the dynamic settings of an index with a JSON document of IndexSettings.
+func (c *Client) PutSettings(ctx context.Context, index string, body []byte) error {
+	_, err := c.do(ctx, http.MethodPut, []string{index, "_settings"}, body)
+	return err
+}
+
+// PutMapping adds fields to the mapping of an index with a document of IndexGenerator.GenerateMappingsJson.
+func (c *Client) PutMapping(ctx context.Context, index string, body []byte) error {
+	_, err := c.do(ctx, http.MethodPut, []string{index, "_mapping"}, body)
+	return err
+}
+
+// GetMapping returns the mapping of an index, keyed by the index name, as OpenSearch returns it. It can be parsed
+// with MappingParser.ParseIndicesJson.
+func (c *Client) GetMapping(ctx context.Context, index string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, []string{index, "_mapping"}, nil)
+}
+
+// PutIndexTemplate creates or replaces an index template with a document of IndexGenerator.GenerateIndexTemplateJson.
+func (c *Client) PutIndexTemplate(ctx context.Context, name string, body []byte) error {
+	_, err := c.do(ctx, http.MethodPut, []string{"_index_template", name}, body)
+	return err
+}
+
+// PutComponentTemplate creates or replaces a component template with a document of
+// IndexGenerator.GenerateComponentTemplateJson.
+func (c *Client) PutComponentTemplate(ctx context.Context, name string, body []byte) error {
+	_, err := c.do(ctx, http.MethodPut, []string{"_component_template", name}, body)
+	return err
+}
+
+// do sends a request to the path made of pathElems and returns the body of a successful response. Each element is a
+// single path segment: characters such as "/" and "?" are percent-encoded, and empty, "." and ".." elements are
+// rejected, so that e.g. an index name cannot address another path. Responses with a status code of 300 or above are
+// returned as *Error.
+func (c *Client) do(ctx context.Context, method string, pathElems []string, body []byte) ([]byte, error) {
+	path := "/" + strings.Join(pathElems, "/")
+	escapedElems := make([]string, len(pathElems))
+	for i, elem := range pathElems {
+		if elem == "" || elem == "." || elem == ".." {
+			return nil, errors.Errorf("%s %s: invalid path element %q", method, path, elem)
+		}
+		escapedElems[i] = url.PathEscape(elem)
+	}
+	u := *c.baseURL
+	u.Path = c.baseURL.Path + path
+	u.RawPath = c.baseURL.EscapedPath() + "/" + strings.Join(escapedElems, "/")
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bodyReader)
+	if err != nil {
+		return nil, errors.Wrapf(err, "http.NewRequestWithContext")
+	}
+	for key, values := range c.optionContainer.header {
+		req.Header[key] = append([]string{}, values...)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.optionContainer.username != "" || c.optionContainer.password != "" {
+		req.SetBasicAuth(c.optionContainer.username, c.optionContainer.password)
+	}
+
+	resp, err := c.optionContainer.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s %s", method, path)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "io.ReadAll")
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, newError(method, path, resp.StatusCode, respBody)
+	}
+	return respBody, nil
+}
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+type recordedRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   string
+}
+
+type testResponse struct {
+	statusCode int
+	body       string
+}
+
+// newTestServer starts a server that records requests and responds to those whose "METHOD /path" is a key of routes
+// with that response, and to the others with the given status code and body.
+func newTestServer(
+	t *testing.T,
+	statusCode int,
+	respBody string,
+	routes map[string]testResponse,
+) (*httptest.Server, *[]recordedRequest) {
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, recordedRequest{
+			method: r.Method,
+			path:   r.URL.EscapedPath(),
+			header: r.Header,
+			body:   string(body),
+		})
+		resp, ok := routes[r.Method+" "+r.URL.EscapedPath()]
+		if !ok {
+			resp = testResponse{statusCode: statusCode, body: respBody}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(resp.statusCode)
+		_, _ = w.Write([]byte(resp.body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+// requestLines formats recorded requests as "METHOD /path body".
+func requestLines(requests []recordedRequest) []string {
+	lines := make([]string, 0, len(requests))
+	for _, r := range requests {
+		lines = append(lines, r.method+" "+r.path+" "+r.body)
+	}
+	return lines
+}
+
+func TestClient_Requests(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server, requests := newTestServer(t, http.StatusOK, `{"acknowledged": true}`, nil)
+	c, err := New(server.URL+"/prefix/", WithBasicAuth("admin", "secret"), WithHeader("X-Tenant", "a"))
+	g.Expect(err).To(gomega.BeNil())
+
+	ctx := context.Background()
+	g.Expect(c.CreateIndex(ctx, "products", []byte(`{"mappings":{}}`))).To(gomega.Succeed())
+	g.Expect(c.PutMapping(ctx, "products", []byte(`{"properties":{}}`))).To(gomega.Succeed())
+	mappingJson, err := c.GetMapping(ctx, "products")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(mappingJson)).To(gomega.Equal(`{"acknowledged": true}`))
+	exists, err := c.IndexExists(ctx, "products")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(exists).To(gomega.BeTrue())
+	_, err = c.GetIndex(ctx, "products")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(c.PutSettings(ctx, "products", []byte(`{"number_of_replicas":2}`))).To(gomega.Succeed())
+	g.Expect(c.PutIndexTemplate(ctx, "logs", []byte(`{"index_patterns":["logs-*"]}`))).To(gomega.Succeed())
+	g.Expect(c.PutComponentTemplate(ctx, "base", []byte(`{"template":{}}`))).To(gomega.Succeed())
+	g.Expect(c.DeleteIndex(ctx, "products")).To(gomega.Succeed())
+
+	type request struct {
+		method string
+		path   string
+		body   string
+	}
+	var got []request
+	for _, r := range *requests {
+		got = append(got, request{method: r.method, path: r.path, body: r.body})
+
+		username, password, ok := (&http.Request{Header: r.header}).BasicAuth()
+		g.Expect(ok).To(gomega.BeTrue())
+		g.Expect(username).To(gomega.Equal("admin"))
+		g.Expect(password).To(gomega.Equal("secret"))
+		g.Expect(r.header.Get("X-Tenant")).To(gomega.Equal("a"))
+		if r.body != "" {
+			g.Expect(r.header.Get("Content-Type")).To(gomega.Equal("application/json"))
+		}
+	}
+	g.Expect(got).To(gomega.Equal([]request{
+		{method: http.MethodPut, path: "/prefix/products", body: `{"mappings":{}}`},
+		{method: http.MethodPut, path: "/prefix/products/_mapping", body: `{"properties":{}}`},
+		{method: http.MethodGet, path: "/prefix/products/_mapping"},
+		{method: http.MethodHead, path: "/prefix/products"},
+		{method: http.MethodGet, path: "/prefix/products"},
+		{method: http.MethodPut, path: "/prefix/products/_settings", body: `{"number_of_replicas":2}`},
+		{method: http.MethodPut, path: "/prefix/_index_template/logs", body: `{"index_patterns":["logs-*"]}`},
+		{method: http.MethodPut, path: "/prefix/_component_template/base", body: `{"template":{}}`},
+		{method: http.MethodDelete, path: "/prefix/products"},
+	}))
+}
+
+func TestClient_IndexExists_NotFound(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server, _ := newTestServer(t, http.StatusNotFound, "", nil)
+	c, err := New(server.URL)
+	g.Expect(err).To(gomega.BeNil())
+
+	exists, err := c.IndexExists(context.Background(), "products")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(exists).To(gomega.BeFalse())
+}
+
+func TestClient_Errors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server, _ := newTestServer(t, http.StatusBadRequest, `{
+		"error": {
+			"root_cause": [
+				{"type": "resource_already_exists_exception", "reason": "index [products/abc] already exists", "index": "products"}
+			],
+			"type": "resource_already_exists_exception",
+			"reason": "index [products/abc] already exists",
+			"index": "products"
+		},
+		"status": 400
+	}`, nil)
+	c, err := New(server.URL)
+	g.Expect(err).To(gomega.BeNil())
+
+	err = c.CreateIndex(context.Background(), "products", []byte(`{}`))
+	g.Expect(err).To(gomega.MatchError(
+		"PUT /products: 400 Bad Request: resource_already_exists_exception: index [products/abc] already exists"))
+	g.Expect(IsAlreadyExists(err)).To(gomega.BeTrue())
+	g.Expect(IsNotFound(err)).To(gomega.BeFalse())
+
+	var e *Error
+	g.Expect(err).To(gomega.BeAssignableToTypeOf(e))
+	e = err.(*Error)
+	g.Expect(e.StatusCode).To(gomega.Equal(http.StatusBadRequest))
+	g.Expect(e.RootCause).To(gomega.Equal([]ErrorCause{{
+		Type:   "resource_already_exists_exception",
+		Reason: "index [products/abc] already exists",
+		Index:  "products",
+	}}))
+}
+
+func TestClient_EscapesPathElements(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server, requests := newTestServer(t, http.StatusOK, `{"acknowledged": true}`, nil)
+	c, err := New(server.URL + "/prefix")
+	g.Expect(err).To(gomega.BeNil())
+
+	ctx := context.Background()
+	g.Expect(c.DeleteIndex(ctx, "logs/../_all")).To(gomega.Succeed())
+	g.Expect(c.PutMapping(ctx, "logs?pretty#x", []byte(`{}`))).To(gomega.Succeed())
+	g.Expect(c.PutIndexTemplate(ctx, "a b%", []byte(`{}`))).To(gomega.Succeed())
+	g.Expect(*requests).To(gomega.HaveLen(3))
+	g.Expect((*requests)[0].path).To(gomega.Equal("/prefix/logs%2F..%2F_all"))
+	g.Expect((*requests)[1].path).To(gomega.Equal("/prefix/logs%3Fpretty%23x/_mapping"))
+	g.Expect((*requests)[2].path).To(gomega.Equal("/prefix/_index_template/a%20b%25"))
+
+	for _, index := range []string{"", ".", ".."} {
+		err = c.DeleteIndex(ctx, index)
+		g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("invalid path element")), index)
+	}
+	g.Expect(*requests).To(gomega.HaveLen(3))
+}
+
+func TestNew_InvalidBaseURL(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	_, err := New("localhost:9200")
+	g.Expect(err).To(gomega.MatchError(`base URL "localhost:9200" must have a scheme and a host`))
+}
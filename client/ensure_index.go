@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/varfrog/opensearchutil"
+)
+
+// EnsureIndexResult tells what EnsureIndex did.
+type EnsureIndexResult struct {
+	// Created is true if the index did not exist and was created
+	Created bool
+
+	// MappingDiff is the difference between the mapping of the existing index and the mapping of the struct. It is
+	// nil if the index was created.
+	MappingDiff *opensearchutil.MappingDiff
+
+	// MappingUpdated is true if fields were added to the mapping or attributes of fields were updated
+	MappingUpdated bool
+
+	// UpdatedSettings are the dynamic settings that were updated, or nil if none were
+	UpdatedSettings *opensearchutil.IndexSettings
+}
+
+// IncompatibleIndexError lists the differences between an existing index and the desired one that cannot be applied
+// to the index. Such an index has to be reindexed into a new one.
+type IncompatibleIndexError struct {
+	Index string
+
+	// BreakingChanges are the mapping changes that the put-mapping API cannot apply
+	BreakingChanges []opensearchutil.MappingChange
+
+	// StaticSettings are the names of the static settings that differ
+	StaticSettings []string
+}
+
+func (e *IncompatibleIndexError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("index " + e.Index + " is incompatible with the desired index")
+	for _, c := range e.BreakingChanges {
+		sb.WriteString("\n" + c.String())
+	}
+	for _, name := range e.StaticSettings {
+		sb.WriteString("\n~ settings." + name + ": static setting changed (breaking)")
+	}
+	return sb.String()
+}
+
+// EnsureIndex makes an index match the mappings of struct obj and settings. It creates the index if it does not
+// exist. Otherwise, it compares the mapping of the index with the mapping of obj, and the settings of the index with
+// settings, and applies the differences: new fields and updatable attributes of fields with the put-mapping API, and
+// dynamic settings with the update-settings API. Settings that are not set in settings are left as they are.
+//
+// If some differences cannot be applied, such as a changed field type, a removed field or a changed static setting,
+// EnsureIndex applies nothing and returns an *IncompatibleIndexError that lists them.
+func (c *Client) EnsureIndex(
+	ctx context.Context,
+	index string,
+	obj interface{},
+	settings *opensearchutil.IndexSettings,
+	options ...EnsureIndexOption,
+) (*EnsureIndexResult, error) {
+	optContainer := ensureIndexOptionContainer{}
+	for _, o := range options {
+		o.apply(&optContainer)
+	}
+	if optContainer.builder == nil {
+		optContainer.builder = opensearchutil.NewMappingPropertiesBuilder()
+	}
+	if optContainer.generator == nil {
+		optContainer.generator = opensearchutil.NewIndexGenerator()
+	}
+
+	mappingProperties, err := optContainer.builder.BuildMappingProperties(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "BuildMappingProperties")
+	}
+
+	exists, err := c.IndexExists(ctx, index)
+	if err != nil {
+		return nil, errors.Wrapf(err, "IndexExists")
+	}
+	if !exists {
+		indexJson, err := optContainer.generator.GenerateIndexJson(
+			mappingProperties, settings, optContainer.generationOptions...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "GenerateIndexJson")
+		}
+		err = c.CreateIndex(ctx, index, indexJson)
+		if err == nil {
+			return &EnsureIndexResult{Created: true}, nil
+		}
+		if !IsAlreadyExists(err) {
+			return nil, errors.Wrapf(err, "CreateIndex")
+		}
+		// The index was created concurrently, update it instead
+	}
+
+	return c.updateIndex(ctx, index, mappingProperties, settings, optContainer)
+}
+
+// updateIndex applies the differences between an existing index and the desired mapping properties and settings.
+func (c *Client) updateIndex(
+	ctx context.Context,
+	index string,
+	mappingProperties []opensearchutil.MappingProperty,
+	settings *opensearchutil.IndexSettings,
+	optContainer ensureIndexOptionContainer,
+) (*EnsureIndexResult, error) {
+	indexJson, err := c.GetIndex(ctx, index)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetIndex")
+	}
+	indices, err := opensearchutil.NewMappingParser().ParseIndicesJson(indexJson)
+	if err != nil {
+		return nil, errors.Wrapf(err, "ParseIndicesJson")
+	}
+	if len(indices) != 1 {
+		return nil, errors.Errorf("%s resolves to %d indices, expected one", index, len(indices))
+	}
+	var current *opensearchutil.ParsedIndex
+	for _, parsedIndex := range indices {
+		current = parsedIndex
+	}
+
+	// Fields that OpenSearch added to dynamic objects of the index at ingest time are not removed fields
+	result := &EnsureIndexResult{
+		MappingDiff: opensearchutil.DiffMappingProperties(
+			current.MappingProperties,
+			mappingProperties,
+			opensearchutil.WithRootDynamic(current.Dynamic),
+		),
+	}
+	staticChanges, dynamicChanges := opensearchutil.DiffIndexSettings(current.Settings, settings)
+	if !result.MappingDiff.IsCompatible() || len(staticChanges) > 0 {
+		return result, &IncompatibleIndexError{
+			Index:           index,
+			BreakingChanges: result.MappingDiff.BreakingChanges(),
+			StaticSettings:  staticChanges,
+		}
+	}
+
+	if !result.MappingDiff.IsEmpty() {
+		mappingsJson, err := optContainer.generator.GenerateMappingsJson(mappingProperties)
+		if err != nil {
+			return nil, errors.Wrapf(err, "GenerateMappingsJson")
+		}
+		if err := c.PutMapping(ctx, index, mappingsJson); err != nil {
+			return nil, errors.Wrapf(err, "PutMapping")
+		}
+		result.MappingUpdated = true
+	}
+
+	if dynamicChanges != nil {
+		settingsJson, err := json.Marshal(dynamicChanges)
+		if err != nil {
+			return nil, errors.Wrapf(err, "json.Marshal")
+		}
+		if err := c.PutSettings(ctx, index, settingsJson); err != nil {
+			return nil, errors.Wrapf(err, "PutSettings")
+		}
+		result.UpdatedSettings = dynamicChanges
+	}
+
+	return result, nil
+}
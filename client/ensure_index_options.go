@@ -0,0 +1,61 @@
+package client
+
+import "github.com/varfrog/opensearchutil"
+
+type EnsureIndexOption interface {
+	apply(*ensureIndexOptionContainer)
+}
+
+type ensureIndexOptionContainer struct {
+	builder           *opensearchutil.MappingPropertiesBuilder
+	generator         *opensearchutil.IndexGenerator
+	generationOptions []opensearchutil.IndexGenerationOption
+}
+
+type mappingPropertiesBuilderOption struct {
+	builder *opensearchutil.MappingPropertiesBuilder
+}
+
+func (c mappingPropertiesBuilderOption) apply(opts *ensureIndexOptionContainer) {
+	opts.builder = c.builder
+}
+
+// WithMappingPropertiesBuilder sets the builder of the mapping properties of the struct. The default is a builder
+// with default options.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithMappingPropertiesBuilder(builder *opensearchutil.MappingPropertiesBuilder) EnsureIndexOption {
+	return mappingPropertiesBuilderOption{builder: builder}
+}
+
+type indexGeneratorOption struct {
+	generator *opensearchutil.IndexGenerator
+}
+
+func (c indexGeneratorOption) apply(opts *ensureIndexOptionContainer) {
+	opts.generator = c.generator
+}
+
+// WithIndexGenerator sets the generator of the documents sent to OpenSearch. The default is a generator with default
+// options.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithIndexGenerator(generator *opensearchutil.IndexGenerator) EnsureIndexOption {
+	return indexGeneratorOption{generator: generator}
+}
+
+type indexGenerationOptionsOption struct {
+	options []opensearchutil.IndexGenerationOption
+}
+
+func (c indexGenerationOptionsOption) apply(opts *ensureIndexOptionContainer) {
+	opts.generationOptions = append(opts.generationOptions, c.options...)
+}
+
+// WithIndexGenerationOptions sets the options of generating the index, e.g. opensearchutil.WithStrictMapping. They
+// only apply when the index is created.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithIndexGenerationOptions(options ...opensearchutil.IndexGenerationOption) EnsureIndexOption {
+	return indexGenerationOptionsOption{options: options}
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/varfrog/opensearchutil"
+)
+
+type ensureIndexTestDoc struct {
+	Title string `opensearch:"type:keyword"`
+	Views int64  `opensearch:"type:long"`
+}
+
+const ensureIndexTestLiveIndex = `{
+	"products": {
+		"aliases": {},
+		"mappings": {
+			"dynamic": "strict",
+			"properties": {
+				"title": {"type": "keyword"}
+			}
+		},
+		"settings": {
+			"index": {
+				"number_of_shards": "1",
+				"number_of_replicas": "1",
+				"uuid": "abc",
+				"creation_date": "1700000000000"
+			}
+		}
+	}
+}`
+
+func TestClient_EnsureIndex_Creates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server, requests := newTestServer(t, http.StatusOK, `{"acknowledged":true}`, map[string]testResponse{
+		"HEAD /products": {statusCode: http.StatusNotFound},
+	})
+	c, err := New(server.URL)
+	g.Expect(err).To(gomega.BeNil())
+
+	result, err := c.EnsureIndex(
+		context.Background(),
+		"products",
+		ensureIndexTestDoc{},
+		&opensearchutil.IndexSettings{NumberOfShards: opensearchutil.MakePtr(uint16(1))},
+		WithIndexGenerator(opensearchutil.NewIndexGenerator(
+			opensearchutil.WithJsonFormatter(opensearchutil.NewCompactJsonFormatter(opensearchutil.KeyOrderDeclaration)),
+		)),
+		WithIndexGenerationOptions(opensearchutil.WithStrictMapping(true)),
+	)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result).To(gomega.Equal(&EnsureIndexResult{Created: true}))
+	g.Expect(requestLines(*requests)).To(gomega.Equal([]string{
+		"HEAD /products ",
+		`PUT /products {"mappings":{"dynamic":"strict","properties":{"title":{"type":"keyword"},` +
+			`"views":{"type":"long"}}},"settings":{"number_of_shards":1}}`,
+	}))
+}
+
+func TestClient_EnsureIndex_Updates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server, requests := newTestServer(t, http.StatusOK, `{"acknowledged":true}`, map[string]testResponse{
+		"GET /products": {statusCode: http.StatusOK, body: ensureIndexTestLiveIndex},
+	})
+	c, err := New(server.URL)
+	g.Expect(err).To(gomega.BeNil())
+
+	result, err := c.EnsureIndex(
+		context.Background(),
+		"products",
+		ensureIndexTestDoc{},
+		&opensearchutil.IndexSettings{
+			NumberOfShards:   opensearchutil.MakePtr(uint16(1)),
+			NumberOfReplicas: opensearchutil.MakePtr(uint16(2)),
+		},
+		WithIndexGenerator(opensearchutil.NewIndexGenerator(
+			opensearchutil.WithJsonFormatter(opensearchutil.NewCompactJsonFormatter(opensearchutil.KeyOrderDeclaration)),
+		)),
+	)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.Created).To(gomega.BeFalse())
+	g.Expect(result.MappingUpdated).To(gomega.BeTrue())
+	g.Expect(result.MappingDiff.Changes).To(gomega.Equal([]opensearchutil.MappingChange{
+		{Path: "views", Kind: opensearchutil.MappingChangeAdded, New: "long"},
+	}))
+	g.Expect(result.UpdatedSettings).To(gomega.Equal(&opensearchutil.IndexSettings{
+		NumberOfReplicas: opensearchutil.MakePtr(uint16(2)),
+	}))
+	g.Expect(requestLines(*requests)).To(gomega.Equal([]string{
+		"HEAD /products ",
+		"GET /products ",
+		`PUT /products/_mapping {"properties":{"title":{"type":"keyword"},"views":{"type":"long"}}}`,
+		`PUT /products/_settings {"number_of_replicas":2}`,
+	}))
+}
+
+func TestClient_EnsureIndex_UpToDate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		Title string `opensearch:"type:keyword"`
+	}
+	server, requests := newTestServer(t, http.StatusOK, `{"acknowledged":true}`, map[string]testResponse{
+		"GET /products": {statusCode: http.StatusOK, body: ensureIndexTestLiveIndex},
+	})
+	c, err := New(server.URL)
+	g.Expect(err).To(gomega.BeNil())
+
+	result, err := c.EnsureIndex(context.Background(), "products", doc{},
+		&opensearchutil.IndexSettings{NumberOfReplicas: opensearchutil.MakePtr(uint16(1))})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.MappingDiff.IsEmpty()).To(gomega.BeTrue())
+	g.Expect(result.MappingUpdated).To(gomega.BeFalse())
+	g.Expect(result.UpdatedSettings).To(gomega.BeNil())
+	g.Expect(requestLines(*requests)).To(gomega.Equal([]string{"HEAD /products ", "GET /products "}))
+}
+
+func TestClient_EnsureIndex_Incompatible(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		Title string
+		Views int64 `opensearch:"type:long"`
+	}
+	server, requests := newTestServer(t, http.StatusOK, `{"acknowledged":true}`, map[string]testResponse{
+		"GET /products": {statusCode: http.StatusOK, body: ensureIndexTestLiveIndex},
+	})
+	c, err := New(server.URL)
+	g.Expect(err).To(gomega.BeNil())
+
+	_, err = c.EnsureIndex(context.Background(), "products", doc{},
+		&opensearchutil.IndexSettings{NumberOfShards: opensearchutil.MakePtr(uint16(3))})
+	g.Expect(err).To(gomega.MatchError(`index products is incompatible with the desired index
+~ title: type changed from "keyword" to "text" (breaking)
+~ settings.number_of_shards: static setting changed (breaking)`))
+
+	var incompatibleErr *IncompatibleIndexError
+	g.Expect(err).To(gomega.BeAssignableToTypeOf(incompatibleErr))
+	g.Expect(requestLines(*requests)).To(gomega.Equal([]string{"HEAD /products ", "GET /products "}))
+}
+
+func TestClient_EnsureIndex_CreatedConcurrently(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server, requests := newTestServer(t, http.StatusOK, `{"acknowledged":true}`, map[string]testResponse{
+		"HEAD /products": {statusCode: http.StatusNotFound},
+		"PUT /products": {
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":{"type":"resource_already_exists_exception","reason":"index [products] already exists"}}`,
+		},
+		"GET /products": {statusCode: http.StatusOK, body: ensureIndexTestLiveIndex},
+	})
+	c, err := New(server.URL)
+	g.Expect(err).To(gomega.BeNil())
+
+	result, err := c.EnsureIndex(context.Background(), "products", ensureIndexTestDoc{}, nil)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.Created).To(gomega.BeFalse())
+	g.Expect(result.MappingUpdated).To(gomega.BeTrue())
+	g.Expect(requestLines(*requests)).To(gomega.HaveLen(4))
+}
+
+func TestClient_EnsureIndex_IgnoresDynamicallyAddedFields(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		Title  string            `opensearch:"type:keyword"`
+		Views  int64             `opensearch:"type:long"`
+		Labels map[string]string `opensearch:"map:object"`
+	}
+	server, requests := newTestServer(t, http.StatusOK, `{"acknowledged":true}`, map[string]testResponse{
+		"GET /products": {statusCode: http.StatusOK, body: `{
+			"products": {
+				"mappings": {
+					"properties": {
+						"title": {"type": "keyword"},
+						"author": {"type": "text"},
+						"labels": {"type": "object", "dynamic": "true", "properties": {"env": {"type": "text"}}}
+					}
+				}
+			}
+		}`},
+	})
+	c, err := New(server.URL)
+	g.Expect(err).To(gomega.BeNil())
+
+	result, err := c.EnsureIndex(
+		context.Background(),
+		"products",
+		doc{},
+		nil,
+		WithIndexGenerator(opensearchutil.NewIndexGenerator(
+			opensearchutil.WithJsonFormatter(opensearchutil.NewCompactJsonFormatter(opensearchutil.KeyOrderDeclaration)),
+		)),
+	)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(result.MappingUpdated).To(gomega.BeTrue())
+	g.Expect(result.MappingDiff.Changes).To(gomega.Equal([]opensearchutil.MappingChange{
+		{Path: "views", Kind: opensearchutil.MappingChangeAdded, New: "long"},
+	}))
+	g.Expect(requestLines(*requests)).To(gomega.Equal([]string{
+		"HEAD /products ",
+		"GET /products ",
+		`PUT /products/_mapping {"properties":{"title":{"type":"keyword"},"views":{"type":"long"},` +
+			`"labels":{"type":"object","dynamic":"true","properties":{}}}}`,
+	}))
+}
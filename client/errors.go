@@ -0,0 +1,87 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error is an error response of OpenSearch.
+type Error struct {
+	Method     string
+	Path       string
+	StatusCode int
+
+	// Type is the type of the error, e.g. "resource_already_exists_exception". It is empty if the response has no
+	// error object, e.g. for HEAD requests.
+	Type   string
+	Reason string
+
+	// RootCause are the underlying errors reported by OpenSearch
+	RootCause []ErrorCause
+
+	// Body is the raw response body
+	Body []byte
+}
+
+// ErrorCause is an item of the "root_cause" of an error response.
+type ErrorCause struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+	Index  string `json:"index,omitempty"`
+}
+
+type errorResponse struct {
+	Error json.RawMessage `json:"error"`
+}
+
+type errorObject struct {
+	Type      string       `json:"type"`
+	Reason    string       `json:"reason"`
+	RootCause []ErrorCause `json:"root_cause"`
+}
+
+func newError(method string, path string, statusCode int, body []byte) *Error {
+	e := &Error{Method: method, Path: path, StatusCode: statusCode, Body: body}
+
+	var resp errorResponse
+	if err := json.Unmarshal(body, &resp); err != nil || len(resp.Error) == 0 {
+		return e
+	}
+	var obj errorObject
+	if err := json.Unmarshal(resp.Error, &obj); err == nil {
+		e.Type = obj.Type
+		e.Reason = obj.Reason
+		e.RootCause = obj.RootCause
+		return e
+	}
+	var reason string
+	if err := json.Unmarshal(resp.Error, &reason); err == nil {
+		e.Reason = reason
+	}
+	return e
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s %s: %d %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Type != "" {
+		msg += ": " + e.Type
+	}
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// IsNotFound tells whether err is an *Error of a 404 response, e.g. of a missing index.
+func IsNotFound(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+// IsAlreadyExists tells whether err is an *Error of creating an index or another resource that already exists.
+func IsAlreadyExists(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.Type == "resource_already_exists_exception"
+}
@@ -0,0 +1,20 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_newError(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	e := newError(http.MethodDelete, "/products", http.StatusNotFound, []byte(`{"error":"no such index","status":404}`))
+	g.Expect(e.Error()).To(gomega.Equal("DELETE /products: 404 Not Found: no such index"))
+	g.Expect(IsNotFound(e)).To(gomega.BeTrue())
+
+	e = newError(http.MethodGet, "/products", http.StatusBadGateway, []byte(`<html>Bad Gateway</html>`))
+	g.Expect(e.Error()).To(gomega.Equal("GET /products: 502 Bad Gateway"))
+	g.Expect(string(e.Body)).To(gomega.Equal(`<html>Bad Gateway</html>`))
+}
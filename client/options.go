@@ -0,0 +1,63 @@
+package client
+
+import "net/http"
+
+type Option interface {
+	apply(*optionContainer)
+}
+
+type optionContainer struct {
+	httpClient *http.Client
+	username   string
+	password   string
+	header     http.Header
+}
+
+type httpClientOption struct {
+	httpClient *http.Client
+}
+
+func (c httpClientOption) apply(opts *optionContainer) {
+	opts.httpClient = c.httpClient
+}
+
+// WithHTTPClient sets the http.Client to send requests with, e.g. one with TLS settings or a timeout. The default is
+// http.DefaultClient.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithHTTPClient(httpClient *http.Client) Option {
+	return httpClientOption{httpClient: httpClient}
+}
+
+type basicAuthOption struct {
+	username string
+	password string
+}
+
+func (c basicAuthOption) apply(opts *optionContainer) {
+	opts.username = c.username
+	opts.password = c.password
+}
+
+// WithBasicAuth authenticates requests with HTTP basic authentication.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithBasicAuth(username string, password string) Option {
+	return basicAuthOption{username: username, password: password}
+}
+
+type headerOption struct {
+	key   string
+	value string
+}
+
+func (c headerOption) apply(opts *optionContainer) {
+	opts.header.Add(c.key, c.value)
+}
+
+// WithHeader adds a header to every request, e.g. an "Authorization" header with a token.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithHeader(key string, value string) Option {
+	return headerOption{key: key, value: value}
+}
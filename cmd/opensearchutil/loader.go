@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/varfrog/opensearchutil"
+)
+
+// directive marks a struct type to generate files for, e.g.
+//
+//	//opensearchutil:generate name=products index_patterns=products-*;products_v*
+const directive = "//opensearchutil:generate"
+
+// goPackage is a Go package as reported by "go list".
+type goPackage struct {
+	Dir        string
+	ImportPath string
+	Name       string
+	GoFiles    []string
+}
+
+// targetType is a struct type of a package to generate files for.
+type targetType struct {
+	TypeName string
+
+	// Name is the base name of the generated files, the snake-cased TypeName by default
+	Name string
+
+	// IndexPatterns are the index patterns of the generated index template
+	IndexPatterns []string
+}
+
+// loadPackage resolves a package pattern, such as ".", to a single package.
+func loadPackage(pattern string) (*goPackage, error) {
+	cmd := exec.Command("go", "list", "-json", pattern)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "go list %s: %s", pattern, strings.TrimSpace(stderr.String()))
+	}
+
+	var pkgs []goPackage
+	decoder := json.NewDecoder(bytes.NewReader(out))
+	for decoder.More() {
+		var pkg goPackage
+		if err := decoder.Decode(&pkg); err != nil {
+			return nil, errors.Wrapf(err, "decoder.Decode")
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	if len(pkgs) != 1 {
+		return nil, errors.Errorf("pattern %s matches %d packages, expected one", pattern, len(pkgs))
+	}
+	return &pkgs[0], nil
+}
+
+// findTargetTypes finds the struct types of a package that are annotated with the directive or listed in typeNames.
+func findTargetTypes(pkg *goPackage, typeNames []string) ([]targetType, error) {
+	listed := make(map[string]bool, len(typeNames))
+	for _, name := range typeNames {
+		listed[name] = true
+	}
+
+	fileSet := token.NewFileSet()
+	var targets []targetType
+	found := make(map[string]bool)
+	for _, fileName := range pkg.GoFiles {
+		file, err := parser.ParseFile(fileSet, filepath.Join(pkg.Dir, fileName), nil, parser.ParseComments)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parser.ParseFile")
+		}
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if _, ok := typeSpec.Type.(*ast.StructType); !ok {
+					continue
+				}
+
+				doc := typeSpec.Doc
+				if doc == nil && len(genDecl.Specs) == 1 {
+					doc = genDecl.Doc
+				}
+				args, annotated := findDirective(doc)
+				if !annotated && !listed[typeSpec.Name.Name] {
+					continue
+				}
+				if !typeSpec.Name.IsExported() {
+					return nil, errors.Errorf("type %s is not exported", typeSpec.Name.Name)
+				}
+
+				target, err := newTargetType(typeSpec.Name.Name, args)
+				if err != nil {
+					return nil, errors.Wrapf(err, "type %s", typeSpec.Name.Name)
+				}
+				targets = append(targets, target)
+				found[typeSpec.Name.Name] = true
+			}
+		}
+	}
+
+	for _, name := range typeNames {
+		if !found[name] {
+			return nil, errors.Errorf("struct type %s not found in package %s", name, pkg.ImportPath)
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].TypeName < targets[j].TypeName
+	})
+	return targets, nil
+}
+
+// findDirective returns the arguments of the directive in a doc comment, if the comment has the directive.
+func findDirective(doc *ast.CommentGroup) ([]string, bool) {
+	if doc == nil {
+		return nil, false
+	}
+	for _, comment := range doc.List {
+		if comment.Text == directive || strings.HasPrefix(comment.Text, directive+" ") {
+			return strings.Fields(strings.TrimPrefix(comment.Text, directive)), true
+		}
+	}
+	return nil, false
+}
+
+func newTargetType(typeName string, args []string) (targetType, error) {
+	name, err := opensearchutil.NewSnakeCaser().TransformFieldName(typeName)
+	if err != nil {
+		return targetType{}, errors.Wrapf(err, "TransformFieldName")
+	}
+
+	target := targetType{TypeName: typeName, Name: name}
+	for _, arg := range args {
+		key, val, ok := strings.Cut(arg, "=")
+		if !ok || val == "" {
+			return targetType{}, errors.Errorf("invalid directive argument %q, expected key=value", arg)
+		}
+		switch key {
+		case "name":
+			target.Name = val
+		case "index_patterns":
+			target.IndexPatterns = strings.Split(val, ";")
+		default:
+			return targetType{}, errors.Errorf("unknown directive argument %q", key)
+		}
+	}
+	return target, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_findTargetTypes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	pkg := &goPackage{Dir: "testdata/models", ImportPath: "example.com/models", GoFiles: []string{"models.go"}}
+
+	targets, err := findTargetTypes(pkg, nil)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(targets).To(gomega.Equal([]targetType{
+		{TypeName: "Customer", Name: "customers"},
+		{TypeName: "Product", Name: "product", IndexPatterns: []string{"products-*", "products_v*"}},
+	}))
+
+	targets, err = findTargetTypes(pkg, []string{"OrderLine"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(targets).To(gomega.Equal([]targetType{
+		{TypeName: "Customer", Name: "customers"},
+		{TypeName: "OrderLine", Name: "order_line"},
+		{TypeName: "Product", Name: "product", IndexPatterns: []string{"products-*", "products_v*"}},
+	}))
+
+	_, err = findTargetTypes(pkg, []string{"Missing"})
+	g.Expect(err).To(gomega.MatchError("struct type Missing not found in package example.com/models"))
+}
+
+func Test_newTargetType(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	target, err := newTargetType("RequestLog", []string{"index_patterns=logs-*"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(target).To(gomega.Equal(targetType{
+		TypeName:      "RequestLog",
+		Name:          "request_log",
+		IndexPatterns: []string{"logs-*"},
+	}))
+
+	_, err = newTargetType("Log", []string{"name"})
+	g.Expect(err).To(gomega.MatchError(`invalid directive argument "name", expected key=value`))
+
+	_, err = newTargetType("Log", []string{"shards=1"})
+	g.Expect(err).To(gomega.MatchError(`unknown directive argument "shards"`))
+}
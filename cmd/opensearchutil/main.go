@@ -0,0 +1,163 @@
+// Command opensearchutil writes index, mapping and index template JSON files for the struct types of a Go package.
+//
+// Struct types are selected with the -types flag, or by annotating them with a directive comment:
+//
+//	//opensearchutil:generate name=products index_patterns=products-*;products_v*
+//	type Product struct {
+//		...
+//	}
+//
+// Both directive arguments are optional: name is the base name of the files (the snake-cased type name by default)
+// and index_patterns are the index patterns of the index template. The tool is meant to be run by go generate:
+//
+//	//go:generate go run github.com/varfrog/opensearchutil/cmd/opensearchutil -dir mappings -strict
+//
+// For every type it writes <name>.index.json (the create-index document), <name>.mappings.json (the put-mapping
+// document) and, for types with index_patterns, <name>.template.json (the create-index-template document). The
+// -outputs flag limits the kinds of files written. No file is written unless the files of all types can be generated.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/varfrog/opensearchutil"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "opensearchutil:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := flag.NewFlagSet("opensearchutil", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: opensearchutil [flags] [package]")
+		flags.PrintDefaults()
+	}
+	outputDir := flags.String("dir", ".", "directory to write the files to")
+	typeNames := flags.String("types", "", "comma-separated names of struct types to generate files for, "+
+		"in addition to the types annotated with "+directive)
+	outputs := flags.String("outputs", outputIndex+","+outputMappings+","+outputTemplate,
+		"comma-separated kinds of files to write: index, mappings, template")
+	maxDepth := flags.Uint("max-depth", opensearchutil.DefaultMaxDepth, "maximum depth of the mappings")
+	strictMapping := flags.Bool("strict", false, `set "dynamic": "strict" on the index mappings`)
+	omitUnsupportedTypes := flags.Bool("omit-unsupported-types", false, "leave fields of unsupported types out")
+	jsonTagFieldNames := flags.Bool("json-tags", false, "name properties after the json tags of fields")
+	keyOrder := flags.String("key-order", "", "order of JSON keys: declaration, alphabetical or type_first "+
+		"(by default keys are sorted alphabetically and indented with three spaces)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return errors.New("expected at most one package")
+	}
+	pattern := "."
+	if flags.NArg() == 1 {
+		pattern = flags.Arg(0)
+	}
+
+	config := generatorConfig{
+		OutputDir:            *outputDir,
+		Outputs:              make(map[string]bool),
+		StrictMapping:        *strictMapping,
+		OmitUnsupportedTypes: *omitUnsupportedTypes,
+		JsonTagFieldNames:    *jsonTagFieldNames,
+		KeyOrder:             *keyOrder,
+	}
+	if *maxDepth == 0 || *maxDepth > 255 {
+		return errors.Errorf("-max-depth must be between 1 and 255, got %d", *maxDepth)
+	}
+	config.MaxDepth = uint8(*maxDepth)
+	for _, output := range splitList(*outputs) {
+		if output != outputIndex && output != outputMappings && output != outputTemplate {
+			return errors.Errorf("unknown output %q", output)
+		}
+		config.Outputs[output] = true
+	}
+	switch opensearchutil.KeyOrder(*keyOrder) {
+	case "",
+		opensearchutil.KeyOrderDeclaration,
+		opensearchutil.KeyOrderAlphabetical,
+		opensearchutil.KeyOrderTypeFirst:
+	default:
+		return errors.Errorf("unknown key order %q", *keyOrder)
+	}
+
+	pkg, err := loadPackage(pattern)
+	if err != nil {
+		return errors.Wrapf(err, "loadPackage")
+	}
+	if pkg.Name == "main" {
+		return errors.Errorf("package %s is a main package, which cannot be imported", pkg.ImportPath)
+	}
+	config.ImportPath = pkg.ImportPath
+
+	config.Types, err = findTargetTypes(pkg, splitList(*typeNames))
+	if err != nil {
+		return errors.Wrapf(err, "findTargetTypes")
+	}
+	if len(config.Types) == 0 {
+		return errors.Errorf("no struct types in package %s are annotated with %s or given by -types",
+			pkg.ImportPath, directive)
+	}
+
+	if config.OutputDir, err = filepath.Abs(config.OutputDir); err != nil {
+		return errors.Wrapf(err, "filepath.Abs")
+	}
+	if err := os.MkdirAll(config.OutputDir, 0o755); err != nil {
+		return errors.Wrapf(err, "os.MkdirAll")
+	}
+
+	return runProgram(pkg.Dir, config, stdout, stderr)
+}
+
+// runProgram generates the program that writes the files and runs it with "go run". The program is placed in a
+// temporary directory under the package directory, so that it is built within the module of the package.
+func runProgram(pkgDir string, config generatorConfig, stdout io.Writer, stderr io.Writer) error {
+	src, err := generateProgram(config)
+	if err != nil {
+		return errors.Wrapf(err, "generateProgram")
+	}
+
+	programDir, err := os.MkdirTemp(pkgDir, "_opensearchutil")
+	if err != nil {
+		return errors.Wrapf(err, "os.MkdirTemp")
+	}
+	defer func() {
+		_ = os.RemoveAll(programDir)
+	}()
+
+	if err := os.WriteFile(filepath.Join(programDir, "main.go"), src, 0o600); err != nil {
+		return errors.Wrapf(err, "os.WriteFile")
+	}
+
+	cmd := exec.Command("go", "run", "main.go")
+	cmd.Dir = programDir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "go run")
+	}
+	return nil
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_run(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go list and go run")
+	}
+	g := gomega.NewGomegaWithT(t)
+
+	outputDir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	err := run([]string{
+		"-dir", outputDir,
+		"-types", "OrderLine",
+		"-outputs", "index,template",
+		"-strict",
+		"-key-order", "declaration",
+		"./testdata/models",
+	}, &stdout, &stderr)
+	g.Expect(err).To(gomega.BeNil(), stderr.String())
+
+	entries, err := os.ReadDir(outputDir)
+	g.Expect(err).To(gomega.BeNil())
+	var fileNames []string
+	for _, entry := range entries {
+		fileNames = append(fileNames, entry.Name())
+	}
+	g.Expect(fileNames).To(gomega.Equal([]string{
+		"customers.index.json",
+		"order_line.index.json",
+		"product.index.json",
+		"product.template.json",
+	}))
+
+	orderLineJson, err := os.ReadFile(filepath.Join(outputDir, "order_line.index.json"))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(orderLineJson)).To(gomega.Equal(`{
+  "mappings": {
+    "dynamic": "strict",
+    "properties": {
+      "product_name": {
+        "type": "keyword"
+      },
+      "quantity": {
+        "type": "integer"
+      }
+    }
+  }
+}
+`))
+}
+
+func Test_run_BuildError(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go list and go run")
+	}
+	g := gomega.NewGomegaWithT(t)
+
+	outputDir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"-dir", outputDir, "-types", "Broken", "./testdata/models"}, &stdout, &stderr)
+	g.Expect(err).NotTo(gomega.BeNil())
+	g.Expect(stderr.String()).To(gomega.ContainSubstring("Broken.Channel (channel): field not supported"))
+
+	entries, err := os.ReadDir(outputDir)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(entries).To(gomega.BeEmpty())
+}
+
+func Test_run_InvalidFlags(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var stdout, stderr bytes.Buffer
+	g.Expect(run([]string{"-outputs", "index,settings"}, &stdout, &stderr)).
+		To(gomega.MatchError(`unknown output "settings"`))
+	g.Expect(run([]string{"-key-order", "random"}, &stdout, &stderr)).
+		To(gomega.MatchError(`unknown key order "random"`))
+	g.Expect(run([]string{"-max-depth", "0"}, &stdout, &stderr)).
+		To(gomega.MatchError("-max-depth must be between 1 and 255, got 0"))
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	outputIndex    = "index"
+	outputMappings = "mappings"
+	outputTemplate = "template"
+)
+
+// generatorConfig configures the program that generates the files.
+type generatorConfig struct {
+	ImportPath           string
+	OutputDir            string
+	Outputs              map[string]bool
+	MaxDepth             uint8
+	StrictMapping        bool
+	OmitUnsupportedTypes bool
+	JsonTagFieldNames    bool
+	KeyOrder             string
+	Types                []targetType
+}
+
+// programTemplate is the source of a program that imports the target package, builds the mapping properties of
+// its types and writes the files. The types can only be reflected on from a compiled program, hence the indirection.
+var programTemplate = template.Must(template.New("program").Parse(`// Code generated by opensearchutil. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/varfrog/opensearchutil"
+	target {{printf "%q" .ImportPath}}
+)
+
+func main() {
+	builder := opensearchutil.NewMappingPropertiesBuilder(
+		opensearchutil.WithMaxDepth({{.MaxDepth}}),
+		{{- if .OmitUnsupportedTypes}}
+		opensearchutil.OmitUnsupportedTypes(),
+		{{- end}}
+		{{- if .JsonTagFieldNames}}
+		opensearchutil.WithJsonTagFieldNames(),
+		{{- end}}
+	)
+	generator := opensearchutil.NewIndexGenerator(
+		{{- if .KeyOrder}}
+		opensearchutil.WithJsonFormatter(opensearchutil.NewOrderedJsonFormatter({{printf "%q" .KeyOrder}}, "  ")),
+		{{- end}}
+	)
+	options := []opensearchutil.IndexGenerationOption{opensearchutil.WithStrictMapping({{.StrictMapping}})}
+	files := make(map[string][]byte)
+	{{range .Types}}
+	{
+		mappingProperties, err := builder.BuildMappingProperties(target.{{.TypeName}}{})
+		check({{printf "%q" .TypeName}}, err)
+		{{- if $.Outputs.index}}
+		files[{{printf "%q" .Name}}+".index.json"], err = generator.GenerateIndexJson(mappingProperties, nil, options...)
+		check({{printf "%q" .TypeName}}, err)
+		{{- end}}
+		{{- if $.Outputs.mappings}}
+		files[{{printf "%q" .Name}}+".mappings.json"], err = generator.GenerateMappingsJson(mappingProperties)
+		check({{printf "%q" .TypeName}}, err)
+		{{- end}}
+		{{- if and $.Outputs.template .IndexPatterns}}
+		files[{{printf "%q" .Name}}+".template.json"], err = generator.GenerateIndexTemplateJson(
+			opensearchutil.IndexTemplate{IndexPatterns: {{printf "%#v" .IndexPatterns}}},
+			mappingProperties,
+			nil,
+			options...,
+		)
+		check({{printf "%q" .TypeName}}, err)
+		{{- end}}
+	}
+	{{- end}}
+
+	fileNames := make([]string, 0, len(files))
+	for fileName := range files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		path := filepath.Join({{printf "%q" .OutputDir}}, fileName)
+		if err := os.WriteFile(path, append(files[fileName], '\n'), 0o644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println("wrote", path)
+	}
+}
+
+func check(typeName string, err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", typeName, err)
+		os.Exit(1)
+	}
+}
+`))
+
+// generateProgram generates the formatted source of the program that writes the files.
+func generateProgram(config generatorConfig) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := programTemplate.Execute(&buf, config); err != nil {
+		return nil, errors.Wrapf(err, "programTemplate.Execute")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "format.Source")
+	}
+	return src, nil
+}
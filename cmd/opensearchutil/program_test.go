@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func Test_generateProgram(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	src, err := generateProgram(generatorConfig{
+		ImportPath:           "example.com/models",
+		OutputDir:            "/out",
+		Outputs:              map[string]bool{outputMappings: true, outputTemplate: true},
+		MaxDepth:             5,
+		OmitUnsupportedTypes: true,
+		Types: []targetType{
+			{TypeName: "Customer", Name: "customers"},
+			{TypeName: "Product", Name: "product", IndexPatterns: []string{"products-*"}},
+		},
+	})
+	g.Expect(err).To(gomega.BeNil())
+
+	program := string(src)
+	g.Expect(program).To(gomega.ContainSubstring(`target "example.com/models"`))
+	g.Expect(program).To(gomega.ContainSubstring("opensearchutil.WithMaxDepth(5),\n\t\topensearchutil.OmitUnsupportedTypes(),\n\t)"))
+	g.Expect(program).To(gomega.ContainSubstring("opensearchutil.WithStrictMapping(false)"))
+	g.Expect(program).To(gomega.ContainSubstring("builder.BuildMappingProperties(target.Customer{})"))
+	g.Expect(program).To(gomega.ContainSubstring(`files["customers"+".mappings.json"]`))
+	g.Expect(program).NotTo(gomega.ContainSubstring(`files["customers"+".template.json"]`))
+	g.Expect(program).To(gomega.ContainSubstring(`files["product"+".template.json"]`))
+	g.Expect(program).To(gomega.ContainSubstring(`IndexPatterns: []string{"products-*"}`))
+	g.Expect(program).NotTo(gomega.ContainSubstring(".index.json"))
+	g.Expect(program).NotTo(gomega.ContainSubstring("WithJsonFormatter"))
+}
@@ -0,0 +1,33 @@
+package models
+
+import "github.com/varfrog/opensearchutil"
+
+//opensearchutil:generate index_patterns=products-*;products_v*
+type Product struct {
+	Name      string `opensearch:"fields.raw:type=keyword"`
+	Price     float64
+	CreatedAt opensearchutil.TimeBasicDateTime
+}
+
+// OrderLine is generated with the -types flag.
+type OrderLine struct {
+	ProductName string `opensearch:"type:keyword"`
+	Quantity    int
+}
+
+type (
+	//opensearchutil:generate name=customers
+	Customer struct {
+		FullName string
+	}
+
+	// Address is not generated.
+	Address struct {
+		City string
+	}
+)
+
+// Broken is generated with the -types flag in tests of failures.
+type Broken struct {
+	Channel chan int
+}
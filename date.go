@@ -0,0 +1,110 @@
+package opensearchutil
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Format is implemented by the format markers of Date: types whose zero value gives the format of a mapping, which
+// can have "||"-separated alternatives, e.g.
+//
+//	type EventTimeFormat struct{}
+//
+//	func (EventTimeFormat) OpenSearchDateFormat() string {
+//		return "strict_date_optional_time||epoch_millis"
+//	}
+//
+//	type Event struct {
+//		Time opensearchutil.Date[EventTimeFormat] `json:"time"`
+//	}
+type Format interface {
+	OpenSearchDateFormat() string
+}
+
+type (
+	// FormatDefault is the format of "date" fields without a format, strict_date_optional_time||epoch_millis.
+	FormatDefault struct{}
+
+	// FormatStrictDateOptionalTime is the strict_date_optional_time format.
+	FormatStrictDateOptionalTime struct{}
+
+	// FormatEpochMillis is the epoch_millis format.
+	FormatEpochMillis struct{}
+
+	// FormatEpochSecond is the epoch_second format.
+	FormatEpochSecond struct{}
+)
+
+func (FormatDefault) OpenSearchDateFormat() string {
+	return defaultDateFormat
+}
+
+func (FormatStrictDateOptionalTime) OpenSearchDateFormat() string {
+	return "strict_date_optional_time"
+}
+
+func (FormatEpochMillis) OpenSearchDateFormat() string {
+	return "epoch_millis"
+}
+
+func (FormatEpochSecond) OpenSearchDateFormat() string {
+	return "epoch_second"
+}
+
+// Date is a time that is mapped as a "date" field of the format given by the marker F, and marshalled with that
+// format. A format with alternatives marshals with the first one and unmarshals with each of them in order, as
+// OpenSearch does. Values of epoch formats marshal into JSON numbers. The formats must be registered, see
+// RegisterDateFormat.
+type Date[F Format] struct{ time.Time }
+
+// NewDate is a constructor for Date.
+//
+//goland:noinspection GoUnusedExportedFunction
+func NewDate[F Format](t time.Time) Date[F] {
+	return Date[F]{t}
+}
+
+// GetOpenSearchDateFieldType makes MappingPropertiesBuilder map Date as a "date" field of the format of F.
+func (d Date[F]) GetOpenSearchDateFieldType() string {
+	var format F
+	return format.OpenSearchDateFormat()
+}
+
+func (d Date[F]) MarshalText() ([]byte, error) {
+	return marshalDateText(d.Time, d.GetOpenSearchDateFieldType())
+}
+
+func (d *Date[F]) UnmarshalText(text []byte) error {
+	return unmarshalDateText(&d.Time, text, d.GetOpenSearchDateFieldType())
+}
+
+// MarshalJSON marshals Date into a JSON string, or into a JSON number if the format is an epoch format.
+func (d Date[F]) MarshalJSON() ([]byte, error) {
+	text, err := d.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	name, _, _ := strings.Cut(d.GetOpenSearchDateFieldType(), "||")
+	if strings.HasPrefix(name, "epoch_") {
+		return text, nil
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON unmarshals Date from a JSON string or number, leaving it as is for null.
+func (d *Date[F]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	text, err := dateTextFromJSON(data)
+	if err != nil {
+		return errors.Wrap(err, "dateTextFromJSON")
+	}
+	if err := d.UnmarshalText(text); err != nil {
+		return errors.Wrap(err, "UnmarshalText")
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+package opensearchutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DateFormat formats and parses the values of a format of OpenSearch "date" fields. The built-in formats of OpenSearch
+// are registered in this package and shared by the Time* types, ValidateDocument and StructGenerator; formats of custom
+// patterns can be added with RegisterDateFormat.
+type DateFormat struct {
+	// Name is the name of the format in mappings, e.g. "strict_date_optional_time"
+	Name string
+
+	// Format formats a time as a value of the format
+	Format func(t time.Time) string
+
+	// Parse parses a value of the format. Values without a time zone are in UTC, as in OpenSearch.
+	Parse func(value string) (time.Time, error)
+}
+
+var (
+	builtInDateFormatNames = make(map[string]bool)
+
+	dateFormatsMu sync.RWMutex
+	dateFormats   = func() map[string]DateFormat {
+		formats := make(map[string]DateFormat)
+		for _, format := range builtInDateFormats() {
+			formats[format.Name] = format
+			builtInDateFormatNames[format.Name] = true
+		}
+		return formats
+	}()
+)
+
+// NewLayoutDateFormat creates a DateFormat that formats times with a Go layout and parses values with the layout or,
+// failing that, with any of parseLayouts. Times of layouts without a date are on 1970-01-01, as in OpenSearch.
+//
+//goland:noinspection GoUnusedExportedFunction
+func NewLayoutDateFormat(name, layout string, parseLayouts ...string) DateFormat {
+	layouts := append([]string{layout}, parseLayouts...)
+	return DateFormat{
+		Name: name,
+		Format: func(t time.Time) string {
+			return t.Format(layout)
+		},
+		Parse: func(value string) (time.Time, error) {
+			var err error
+			for _, l := range layouts {
+				var t time.Time
+				if t, err = time.Parse(l, value); err == nil {
+					if !strings.Contains(l, "2006") {
+						t = t.AddDate(1970, 0, 0)
+					}
+					return t, nil
+				}
+			}
+			return time.Time{}, errors.Wrap(err, "time.Parse")
+		},
+	}
+}
+
+// RegisterDateFormat adds a format to the registry of date formats, or replaces a custom format of the same name. It is
+// meant for the custom patterns of mappings, e.g. NewLayoutDateFormat("yyyy/MM/dd", "2006/01/02"). Built-in formats
+// cannot be replaced, since the Time* types and Date marshal with them: registering one returns ErrBuiltInDateFormat.
+//
+//goland:noinspection GoUnusedExportedFunction
+func RegisterDateFormat(format DateFormat) error {
+	if builtInDateFormatNames[format.Name] {
+		return errors.Wrapf(ErrBuiltInDateFormat, "%q", format.Name)
+	}
+	dateFormatsMu.Lock()
+	defer dateFormatsMu.Unlock()
+	dateFormats[format.Name] = format
+	return nil
+}
+
+// LookupDateFormat returns the registered date format of the given name.
+func LookupDateFormat(name string) (DateFormat, bool) {
+	dateFormatsMu.RLock()
+	defer dateFormatsMu.RUnlock()
+	format, ok := dateFormats[name]
+	return format, ok
+}
+
+// FormatDate formats a time with a format of a mapping. A format with "||"-separated alternatives formats with the
+// first one, as OpenSearch does.
+func FormatDate(t time.Time, format string) (string, error) {
+	name, _, _ := strings.Cut(format, "||")
+	dateFormat, ok := LookupDateFormat(name)
+	if !ok {
+		return "", errors.Wrapf(ErrUnknownDateFormat, "%q", name)
+	}
+	return dateFormat.Format(t), nil
+}
+
+// ParseDate parses a value with a format of a mapping, trying each of its "||"-separated alternatives in order, as
+// OpenSearch does. If no alternative parses the value and some alternatives are not registered, the error is
+// ErrUnknownDateFormat, since the value might be of one of those.
+func ParseDate(value, format string) (time.Time, error) {
+	var unknown []string
+	for _, name := range strings.Split(format, "||") {
+		dateFormat, ok := LookupDateFormat(name)
+		if !ok {
+			unknown = append(unknown, strconv.Quote(name))
+			continue
+		}
+		if t, err := dateFormat.Parse(value); err == nil {
+			return t, nil
+		}
+	}
+	if len(unknown) > 0 {
+		return time.Time{}, errors.Wrapf(ErrUnknownDateFormat, "%s", strings.Join(unknown, ", "))
+	}
+	return time.Time{}, fmt.Errorf("value %q does not match date format %q", value, format)
+}
+
+func builtInDateFormats() []DateFormat {
+	const (
+		zone      = "Z07:00"
+		basicZone = "Z0700"
+	)
+	optionalTimeLayouts := []string{"2006", "2006-01", "2006-01-02"}
+	for _, timeLayout := range []string{"15", "15:04", "15:04:05"} {
+		for _, zoneLayout := range []string{"", "Z07", zone, basicZone} {
+			optionalTimeLayouts = append(optionalTimeLayouts, "2006-01-02T"+timeLayout+zoneLayout)
+		}
+	}
+
+	formats := []DateFormat{
+		newEpochDateFormat("epoch_millis", time.Millisecond),
+		newEpochDateFormat("epoch_second", time.Second),
+
+		NewLayoutDateFormat("date_optional_time", "2006-01-02T15:04:05.000"+zone, optionalTimeLayouts...),
+		NewLayoutDateFormat("strict_date_optional_time_nanos", "2006-01-02T15:04:05.000000000"+zone,
+			optionalTimeLayouts...),
+
+		NewLayoutDateFormat("basic_date", FormatTimeBasicDate),
+		// The Time* types have always formatted these with a "-07:00" offset, OpenSearch formats them with "Z" or
+		// "+0100", so both are parsed.
+		NewLayoutDateFormat("basic_date_time", FormatTimeBasicDateTime, "20060102T150405.000"+basicZone),
+		NewLayoutDateFormat("basic_date_time_no_millis", FormatTimeBasicDateTimeNoMillis, "20060102T150405"+basicZone),
+		NewLayoutDateFormat("basic_ordinal_date", "2006002"),
+		NewLayoutDateFormat("basic_ordinal_date_time", "2006002T150405.000"+basicZone),
+		NewLayoutDateFormat("basic_ordinal_date_time_no_millis", "2006002T150405"+basicZone),
+		NewLayoutDateFormat("basic_time", "150405.000"+basicZone),
+		NewLayoutDateFormat("basic_time_no_millis", "150405"+basicZone),
+		NewLayoutDateFormat("basic_t_time", "T150405.000"+basicZone),
+		NewLayoutDateFormat("basic_t_time_no_millis", "T150405"+basicZone),
+		newWeekDateFormat("basic_week_date", "", 3, ""),
+		newWeekDateFormat("basic_week_date_time", "", 3, "T150405.000"+basicZone),
+		newWeekDateFormat("basic_week_date_time_no_millis", "", 3, "T150405"+basicZone),
+
+		NewLayoutDateFormat("date", "2006-01-02"),
+		NewLayoutDateFormat("date_hour", "2006-01-02T15"),
+		NewLayoutDateFormat("date_hour_minute", "2006-01-02T15:04"),
+		NewLayoutDateFormat("date_hour_minute_second", "2006-01-02T15:04:05"),
+		NewLayoutDateFormat("date_hour_minute_second_fraction", "2006-01-02T15:04:05.000", "2006-01-02T15:04:05"),
+		NewLayoutDateFormat("date_hour_minute_second_millis", "2006-01-02T15:04:05.000"),
+		NewLayoutDateFormat("date_time", "2006-01-02T15:04:05.000"+zone),
+		NewLayoutDateFormat("date_time_no_millis", "2006-01-02T15:04:05"+zone),
+		NewLayoutDateFormat("hour", "15"),
+		NewLayoutDateFormat("hour_minute", "15:04"),
+		NewLayoutDateFormat("hour_minute_second", "15:04:05"),
+		NewLayoutDateFormat("hour_minute_second_fraction", "15:04:05.000", "15:04:05"),
+		NewLayoutDateFormat("hour_minute_second_millis", "15:04:05.000"),
+		NewLayoutDateFormat("ordinal_date", "2006-002"),
+		NewLayoutDateFormat("ordinal_date_time", "2006-002T15:04:05.000"+zone),
+		NewLayoutDateFormat("ordinal_date_time_no_millis", "2006-002T15:04:05"+zone),
+		NewLayoutDateFormat("time", "15:04:05.000"+zone),
+		NewLayoutDateFormat("time_no_millis", "15:04:05"+zone),
+		NewLayoutDateFormat("t_time", "T15:04:05.000"+zone),
+		NewLayoutDateFormat("t_time_no_millis", "T15:04:05"+zone),
+		newWeekDateFormat("week_date", "-", 3, ""),
+		newWeekDateFormat("week_date_time", "-", 3, "T15:04:05.000"+zone),
+		newWeekDateFormat("week_date_time_no_millis", "-", 3, "T15:04:05"+zone),
+		newWeekDateFormat("weekyear", "-", 1, ""),
+		newWeekDateFormat("weekyear_week", "-", 2, ""),
+		newWeekDateFormat("weekyear_week_day", "-", 3, ""),
+		NewLayoutDateFormat("year", "2006"),
+		NewLayoutDateFormat("year_month", "2006-01"),
+		NewLayoutDateFormat("year_month_day", "2006-01-02"),
+	}
+
+	// Most formats have a strict_ variant, which only differs in OpenSearch by requiring zero-padded numbers. Go
+	// layouts require them in both.
+	for _, format := range formats {
+		if strings.HasPrefix(format.Name, "epoch_") || strings.HasPrefix(format.Name, "strict_") ||
+			strings.HasPrefix(format.Name, "basic_") && !strings.HasPrefix(format.Name, "basic_week_date") {
+			continue
+		}
+		strict := format
+		strict.Name = "strict_" + format.Name
+		formats = append(formats, strict)
+	}
+	return formats
+}
+
+// newEpochDateFormat creates a DateFormat of the number of units since the epoch, e.g. epoch_millis. Values can have
+// a fraction, e.g. "1706695200123.456".
+func newEpochDateFormat(name string, unit time.Duration) DateFormat {
+	return DateFormat{
+		Name: name,
+		Format: func(t time.Time) string {
+			return strconv.FormatInt(t.Unix()*int64(time.Second/unit)+int64(t.Nanosecond())/int64(unit), 10)
+		},
+		Parse: func(value string) (time.Time, error) {
+			whole, fraction, hasFraction := strings.Cut(value, ".")
+			units, err := strconv.ParseInt(whole, 10, 64)
+			if err != nil {
+				return time.Time{}, errors.Wrap(err, "strconv.ParseInt")
+			}
+			var fractionNanos int64
+			if hasFraction {
+				if len(fraction) > 9 {
+					fraction = fraction[:9]
+				}
+				f, err := strconv.ParseUint(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+				if err != nil {
+					return time.Time{}, errors.Wrap(err, "strconv.ParseUint")
+				}
+				fractionNanos = int64(f) * int64(unit) / int64(time.Second)
+				if strings.HasPrefix(whole, "-") {
+					fractionNanos = -fractionNanos
+				}
+			}
+			perSecond := int64(time.Second / unit)
+			return time.Unix(units/perSecond, units%perSecond*int64(unit)+fractionNanos).UTC(), nil
+		},
+	}
+}
+
+// newWeekDateFormat creates a DateFormat of an ISO week date: the week-based year, followed by the week and the day
+// of the week if parts is 2 or 3, e.g. "2024-W05-3" with separator "-". A non-empty timeLayout is the Go layout of the
+// time that follows the date.
+func newWeekDateFormat(name, separator string, parts int, timeLayout string) DateFormat {
+	return DateFormat{
+		Name: name,
+		Format: func(t time.Time) string {
+			year, week := t.ISOWeek()
+			s := fmt.Sprintf("%04d", year)
+			if parts > 1 {
+				s += fmt.Sprintf("%sW%02d", separator, week)
+			}
+			if parts > 2 {
+				weekday := int(t.Weekday())
+				if weekday == 0 {
+					weekday = 7
+				}
+				s += fmt.Sprintf("%s%d", separator, weekday)
+			}
+			if timeLayout != "" {
+				s += t.Format(timeLayout)
+			}
+			return s
+		},
+		Parse: func(value string) (time.Time, error) {
+			return parseWeekDate(value, separator, parts, timeLayout)
+		},
+	}
+}
+
+func parseWeekDate(value, separator string, parts int, timeLayout string) (time.Time, error) {
+	invalid := fmt.Errorf("invalid week date %q", value)
+
+	rest := value
+	readNumber := func(prefix string, digits int) (int, bool) {
+		if !strings.HasPrefix(rest, prefix) || len(rest) < len(prefix)+digits {
+			return 0, false
+		}
+		n, err := strconv.Atoi(rest[len(prefix) : len(prefix)+digits])
+		if err != nil || n < 0 {
+			return 0, false
+		}
+		rest = rest[len(prefix)+digits:]
+		return n, true
+	}
+
+	year, ok := readNumber("", 4)
+	week, weekday := 1, 1
+	if ok && parts > 1 {
+		week, ok = readNumber(separator+"W", 2)
+	}
+	if ok && parts > 2 {
+		weekday, ok = readNumber(separator, 1)
+	}
+	if !ok || week < 1 || week > 53 || weekday < 1 || weekday > 7 {
+		return time.Time{}, invalid
+	}
+
+	// Week 1 is the week with January 4th
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	mondayOffset := (int(jan4.Weekday()) + 6) % 7
+	date := jan4.AddDate(0, 0, -mondayOffset+(week-1)*7+weekday-1)
+	if y, w := date.ISOWeek(); y != year || w != week {
+		return time.Time{}, invalid
+	}
+
+	if timeLayout == "" {
+		if rest != "" {
+			return time.Time{}, invalid
+		}
+		return date, nil
+	}
+	clock, err := time.Parse(timeLayout, rest)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "time.Parse")
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(),
+		clock.Nanosecond(), clock.Location()), nil
+}
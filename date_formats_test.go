@@ -0,0 +1,172 @@
+package opensearchutil
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestDateFormats_FormatAndParse(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	someTime := time.Date(2024, 1, 31, 21, 34, 46, 567000000, time.UTC)
+	tests := []struct {
+		format string
+		value  string
+		parsed time.Time // Defaults to someTime
+	}{
+		{format: "epoch_millis", value: "1706736886567"},
+		{format: "epoch_second", value: "1706736886", parsed: someTime.Truncate(time.Second)},
+		{format: "strict_date_optional_time", value: "2024-01-31T21:34:46.567Z"},
+		{format: "strict_date_optional_time_nanos", value: "2024-01-31T21:34:46.567000000Z"},
+		{format: "basic_date", value: "20240131", parsed: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{format: "basic_date_time", value: "20240131T213446.567+00:00"},
+		{format: "basic_ordinal_date", value: "2024031", parsed: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{format: "basic_ordinal_date_time", value: "2024031T213446.567Z"},
+		{format: "basic_week_date", value: "2024W033", parsed: time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{format: "basic_week_date_time_no_millis", value: "2024W053T213446Z", parsed: someTime.Truncate(time.Second)},
+		{format: "date", value: "2024-01-31", parsed: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{format: "strict_date", value: "2024-01-31", parsed: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{format: "date_hour_minute_second_millis", value: "2024-01-31T21:34:46.567"},
+		{format: "date_time", value: "2024-01-31T21:34:46.567Z"},
+		{format: "date_time_no_millis", value: "2024-01-31T21:34:46Z", parsed: someTime.Truncate(time.Second)},
+		{format: "hour_minute_second", value: "21:34:46", parsed: time.Date(1970, 1, 1, 21, 34, 46, 0, time.UTC)},
+		{format: "ordinal_date_time", value: "2024-031T21:34:46.567Z"},
+		{format: "t_time", value: "T21:34:46.567Z", parsed: time.Date(1970, 1, 1, 21, 34, 46, 567000000, time.UTC)},
+		{format: "week_date", value: "2024-W05-3", parsed: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{format: "strict_week_date_time", value: "2024-W05-3T21:34:46.567Z"},
+		{format: "weekyear", value: "2024", parsed: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{format: "weekyear_week", value: "2024-W05", parsed: time.Date(2024, 1, 29, 0, 0, 0, 0, time.UTC)},
+		{format: "year_month", value: "2024-01", parsed: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		parsed := test.parsed
+		if parsed.IsZero() {
+			parsed = someTime
+		}
+
+		value, err := FormatDate(parsed, test.format)
+		g.Expect(err).To(gomega.BeNil(), test.format)
+		g.Expect(value).To(gomega.Equal(test.value), test.format)
+
+		parsedValue, err := ParseDate(test.value, test.format)
+		g.Expect(err).To(gomega.BeNil(), test.format)
+		g.Expect(parsedValue.Equal(parsed)).To(gomega.BeTrue(), "%s: %s", test.format, parsedValue)
+	}
+}
+
+func TestDateFormats_ParseLenientValues(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	for value, expected := range map[string]time.Time{
+		"2024":                      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"2024-01-31":                time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		"2024-01-31T21":             time.Date(2024, 1, 31, 21, 0, 0, 0, time.UTC),
+		"2024-01-31T21:34:46+02:00": time.Date(2024, 1, 31, 19, 34, 46, 0, time.UTC),
+		"2024-01-31T21:34:46.5Z":    time.Date(2024, 1, 31, 21, 34, 46, 500000000, time.UTC),
+	} {
+		parsed, err := ParseDate(value, "strict_date_optional_time")
+		g.Expect(err).To(gomega.BeNil(), value)
+		g.Expect(parsed.Equal(expected)).To(gomega.BeTrue(), value)
+	}
+
+	parsed, err := ParseDate("1706736886567.5", "epoch_millis")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(parsed).To(gomega.Equal(time.Date(2024, 1, 31, 21, 34, 46, 567500000, time.UTC)))
+
+	parsed, err = ParseDate("-1.5", "epoch_second")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(parsed).To(gomega.Equal(time.Date(1969, 12, 31, 23, 59, 58, 500000000, time.UTC)))
+
+	for _, value := range []string{"2024-W54-1", "2024-W05-8", "2024-W05", "2024-W05-3x"} {
+		_, err := ParseDate(value, "week_date")
+		g.Expect(err).NotTo(gomega.BeNil(), value)
+	}
+}
+
+func TestDateFormats_ParseValuesFormattedByOpenSearch(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tests := []struct {
+		format string
+		value  string
+		parsed time.Time
+	}{
+		{"basic_date_time", "20240101T000000.500Z", time.Date(2024, 1, 1, 0, 0, 0, 500000000, time.UTC)},
+		{"basic_date_time", "20240101T000000.500+0100", time.Date(2023, 12, 31, 23, 0, 0, 500000000, time.UTC)},
+		{"basic_date_time", "20240101T000000.5+01:00", time.Date(2023, 12, 31, 23, 0, 0, 500000000, time.UTC)},
+		{"basic_date_time_no_millis", "20240101T000000Z", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"basic_date_time_no_millis", "20240101T000000+0100", time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)},
+		{"basic_date_time_no_millis", "20240101T000000+01:00", time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		parsed, err := ParseDate(test.value, test.format)
+		g.Expect(err).To(gomega.BeNil(), test.value)
+		g.Expect(parsed.Equal(test.parsed)).To(gomega.BeTrue(), "%s: %s", test.value, parsed)
+	}
+
+	var basicDateTime TimeBasicDateTime
+	g.Expect(basicDateTime.UnmarshalText([]byte("20240101T000000.500Z"))).To(gomega.Succeed())
+	g.Expect(time.Time(basicDateTime).Equal(tests[0].parsed)).To(gomega.BeTrue())
+	var noMillis TimeBasicDateTimeNoMillis
+	g.Expect(noMillis.UnmarshalText([]byte("20240101T000000Z"))).To(gomega.Succeed())
+	g.Expect(time.Time(noMillis).Equal(tests[3].parsed)).To(gomega.BeTrue())
+}
+
+func TestParseDate_Alternatives(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	parsed, err := ParseDate("1706736886", "basic_date||epoch_second")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(parsed).To(gomega.Equal(time.Date(2024, 1, 31, 21, 34, 46, 0, time.UTC)))
+
+	_, err = ParseDate("31/01/2024", "basic_date||epoch_second")
+	g.Expect(err).To(gomega.MatchError(`value "31/01/2024" does not match date format "basic_date||epoch_second"`))
+
+	_, err = ParseDate("31/01/2024", "basic_date||dd/MM/yyyy")
+	g.Expect(errors.Is(err, ErrUnknownDateFormat)).To(gomega.BeTrue())
+	g.Expect(err).To(gomega.MatchError(`"dd/MM/yyyy": unknown date format`))
+
+	value, err := FormatDate(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), "basic_date||epoch_second")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(value).To(gomega.Equal("20240131"))
+
+	_, err = FormatDate(time.Now(), "dd/MM/yyyy||basic_date")
+	g.Expect(errors.Is(err, ErrUnknownDateFormat)).To(gomega.BeTrue())
+}
+
+func TestRegisterDateFormat(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(RegisterDateFormat(NewLayoutDateFormat("dd.MM.yyyy", "02.01.2006"))).To(gomega.Succeed())
+
+	format, ok := LookupDateFormat("dd.MM.yyyy")
+	g.Expect(ok).To(gomega.BeTrue())
+	g.Expect(format.Format(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))).To(gomega.Equal("31.01.2024"))
+
+	parsed, err := ParseDate("31.01.2024", "basic_date||dd.MM.yyyy")
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(parsed).To(gomega.Equal(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)))
+
+	mps := []MappingProperty{{FieldName: "born", FieldType: "date", FieldFormat: MakePtr("dd.MM.yyyy")}}
+	g.Expect(ValidateDocument(mps, []byte(`{"born": "31.01.2024"}`))).To(gomega.Succeed())
+	g.Expect(ValidateDocument(mps, []byte(`{"born": "2024-01-31"}`))).NotTo(gomega.Succeed())
+}
+
+func TestRegisterDateFormat_RefusesBuiltInFormats(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	for _, name := range []string{"date", "basic_date_time", "strict_date", "epoch_millis"} {
+		err := RegisterDateFormat(NewLayoutDateFormat(name, "02.01.2006"))
+		g.Expect(errors.Is(err, ErrBuiltInDateFormat)).To(gomega.BeTrue(), name)
+	}
+	g.Expect(RegisterDateFormat(NewLayoutDateFormat("date", "02.01.2006"))).
+		To(gomega.MatchError(`"date": built-in date formats cannot be replaced`))
+
+	// The Time* types keep marshalling with the built-in formats
+	text, err := TimeDate(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)).MarshalText()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(text)).To(gomega.Equal("2024-01-31"))
+}
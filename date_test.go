@@ -0,0 +1,108 @@
+package opensearchutil
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+type dateTestWeekFormat struct{}
+
+func (dateTestWeekFormat) OpenSearchDateFormat() string {
+	return "week_date||strict_date_optional_time"
+}
+
+type dateTestUnknownFormat struct{}
+
+func (dateTestUnknownFormat) OpenSearchDateFormat() string {
+	return "dd/MM/yyyy"
+}
+
+func TestDate_JSONMarshallingAndUnmarshalling(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type event struct {
+		Week    Date[dateTestWeekFormat]           `json:"week"`
+		At      Date[FormatDefault]                `json:"at"`
+		Millis  Date[FormatEpochMillis]            `json:"millis"`
+		Seconds *Date[FormatEpochSecond]           `json:"seconds"`
+		Local   []Date[FormatDefault]              `json:"local"`
+		Strict  Date[FormatStrictDateOptionalTime] `json:"strict"`
+	}
+
+	someTime := time.Date(2024, 1, 31, 21, 34, 46, 567000000, time.UTC)
+	jsonBytes, err := json.Marshal(event{
+		Week:   NewDate[dateTestWeekFormat](someTime),
+		At:     NewDate[FormatDefault](someTime),
+		Millis: NewDate[FormatEpochMillis](someTime),
+		Strict: NewDate[FormatStrictDateOptionalTime](someTime),
+	})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(jsonBytes)).To(gomega.MatchJSON(`{
+		"week": "2024-W05-3",
+		"at": "2024-01-31T21:34:46.567Z",
+		"millis": 1706736886567,
+		"seconds": null,
+		"local": null,
+		"strict": "2024-01-31T21:34:46.567Z"
+	}`))
+
+	// Each alternative of the format is tried in order
+	var parsed event
+	g.Expect(json.Unmarshal([]byte(`{
+		"week": "2024-01-31T21:34:46.567Z",
+		"at": 1706736886567,
+		"millis": "1706736886567",
+		"seconds": 1706736886,
+		"local": ["2024-01-31", "2024-01-31T21:34:46.567+02:00"]
+	}`), &parsed)).To(gomega.Succeed())
+	g.Expect(parsed.Week.Equal(someTime)).To(gomega.BeTrue())
+	g.Expect(parsed.At.Equal(someTime)).To(gomega.BeTrue())
+	g.Expect(parsed.Millis.Equal(someTime)).To(gomega.BeTrue())
+	g.Expect(parsed.Seconds.Equal(someTime.Truncate(time.Second))).To(gomega.BeTrue())
+	g.Expect(parsed.Local[0].Equal(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))).To(gomega.BeTrue())
+	g.Expect(parsed.Local[1].Equal(someTime.Add(-2 * time.Hour))).To(gomega.BeTrue())
+
+	err = json.Unmarshal([]byte(`{"week": "31/01/2024"}`), &parsed)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(
+		`value "31/01/2024" does not match date format "week_date||strict_date_optional_time"`)))
+
+	// Values are decoded as JSON strings or numbers
+	var escaped Date[FormatDefault]
+	g.Expect(json.Unmarshal([]byte(`"2024\u002d01-31"`), &escaped)).To(gomega.Succeed())
+	g.Expect(escaped.Equal(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))).To(gomega.BeTrue())
+	for _, value := range []string{`"2024-01-31`, `2024-01-31"`, `true`, `{}`} {
+		g.Expect(escaped.UnmarshalJSON([]byte(value))).NotTo(gomega.Succeed(), value)
+	}
+}
+
+func TestDate_UnknownFormat(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	_, err := json.Marshal(NewDate[dateTestUnknownFormat](time.Now()))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`"dd/MM/yyyy": unknown date format`)))
+}
+
+func TestDate_MappingProperties(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type event struct {
+		Week   Date[dateTestWeekFormat]  `json:"week"`
+		At     *Date[FormatDefault]      `json:"at"`
+		Millis []Date[FormatEpochMillis] `json:"millis"`
+	}
+
+	mps, err := NewMappingPropertiesBuilder(WithJsonTagFieldNames()).BuildMappingProperties(event{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "week", FieldType: "date", FieldFormat: MakePtr("week_date||strict_date_optional_time")},
+		{FieldName: "at", FieldType: "date", FieldFormat: MakePtr("strict_date_optional_time||epoch_millis")},
+		{FieldName: "millis", FieldType: "date", FieldFormat: MakePtr("epoch_millis")},
+	}))
+
+	// Documents of Date fields validate against the mapping built from them
+	doc := event{Week: NewDate[dateTestWeekFormat](time.Now()), Millis: []Date[FormatEpochMillis]{{time.Now()}}}
+	g.Expect(ValidateDocument(mps, doc)).To(gomega.Succeed())
+}
@@ -0,0 +1,277 @@
+package opensearchutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// defaultDateFormat is the format of "date" and "date_nanos" fields without a "format".
+const defaultDateFormat = "strict_date_optional_time||epoch_millis"
+
+// integerFieldRanges are the value ranges of the integer field types.
+var integerFieldRanges = map[string][2]*big.Int{
+	"byte":          {big.NewInt(math.MinInt8), big.NewInt(math.MaxInt8)},
+	"short":         {big.NewInt(math.MinInt16), big.NewInt(math.MaxInt16)},
+	"integer":       {big.NewInt(math.MinInt32), big.NewInt(math.MaxInt32)},
+	"long":          {big.NewInt(math.MinInt64), big.NewInt(math.MaxInt64)},
+	"unsigned_long": {big.NewInt(0), new(big.Int).SetUint64(math.MaxUint64)},
+}
+
+// floatFieldMaxValues are the largest absolute values of the floating point field types.
+var floatFieldMaxValues = map[string]float64{
+	"double":       math.MaxFloat64,
+	"float":        math.MaxFloat32,
+	"half_float":   65504,
+	"scaled_float": math.MaxFloat64,
+}
+
+// stringFieldTypes are the field types that take strings. OpenSearch also indexes numbers and booleans into them as
+// strings.
+var stringFieldTypes = map[string]bool{
+	"text": true, "keyword": true, "constant_keyword": true, "wildcard": true, "match_only_text": true,
+	"search_as_you_type": true, "ip": true, "version": true,
+}
+
+// ValidateDocument checks a document against mapping properties before it is indexed, reporting what OpenSearch would
+// reject: fields that are not in the mapping, values whose JSON type does not fit the field type (e.g. a string into a
+// "long" field), integers out of the range of their field type, and strings of "date" fields that do not parse under
+// the format of the property. Unknown fields are checked against the dynamic template of map fields, and are otherwise
+// reported only where the mapping is strict. The root mapping is taken as strict, as in the indices of IndexGenerator
+// with WithStrictMapping, unless WithDocumentRootDynamic says otherwise; objects without a "dynamic" of their own
+// inherit the setting of their parent.
+//
+// document is either raw JSON, as a []byte or a json.RawMessage, or a Go value that is marshalled with encoding/json.
+// All problems are reported in a single FieldErrors error, with the dotted paths of the fields.
+func ValidateDocument(
+	mappingProperties []MappingProperty,
+	document interface{},
+	options ...DocumentValidationOption,
+) error {
+	optContainer := documentValidationOptionContainer{}
+	for _, o := range options {
+		o.apply(&optContainer)
+	}
+
+	var raw []byte
+	switch doc := document.(type) {
+	case []byte:
+		raw = doc
+	case json.RawMessage:
+		raw = doc
+	default:
+		var err error
+		if raw, err = json.Marshal(document); err != nil {
+			return errors.Wrapf(err, "json.Marshal")
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return errors.Wrapf(err, "Decode")
+	}
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("a document must be a JSON object, got %s", jsonTypeName(value))
+	}
+
+	var v documentValidator
+	rootDynamic := isDynamicRoot(optContainer.rootDynamicSet, optContainer.rootDynamic)
+	v.validateObject("", mappingProperties, nil, rootDynamic, obj)
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+type documentValidator struct {
+	errs FieldErrors
+}
+
+func (v *documentValidator) addError(path string, format string, args ...interface{}) {
+	v.errs = append(v.errs, &FieldError{Path: path, Reason: fmt.Sprintf(format, args...)})
+}
+
+// validateObject validates the fields of an object whose properties are mps. owner is the property of the object, nil
+// for the document itself. dynamic tells whether OpenSearch adds unknown fields to the object.
+func (v *documentValidator) validateObject(prefix string, mps []MappingProperty, owner *MappingProperty, dynamic bool,
+	obj map[string]interface{},
+) {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		path := prefix + key
+		if mp := findMappingProperty(mps, []string{key}); mp != nil {
+			v.validateValue(path, *mp, dynamic, obj[key])
+			continue
+		}
+
+		// OpenSearch expands fields with dots in their names into objects, e.g. {"a.b": 1} into {"a": {"b": 1}}
+		if parent, child, found := strings.Cut(key, "."); found {
+			if mp := findMappingProperty(mps, []string{parent}); mp != nil {
+				v.validateValue(prefix+parent, *mp, dynamic, map[string]interface{}{child: obj[key]})
+				continue
+			}
+		}
+
+		switch {
+		case owner != nil && owner.DynamicTemplate != nil:
+			v.validateValue(path, *owner.DynamicTemplate, dynamic, obj[key])
+		case dynamic:
+		default:
+			v.addError(path, "field is not in the mapping")
+		}
+	}
+}
+
+// validateValue validates the value of a field whose property is mp. parentDynamic tells whether OpenSearch adds
+// unknown fields to the object that holds the field, which objects without a "dynamic" of their own inherit.
+func (v *documentValidator) validateValue(path string, mp MappingProperty, parentDynamic bool, value interface{}) {
+	switch val := value.(type) {
+	case nil:
+		return
+	case []interface{}:
+		// Any field can hold an array of values of its type
+		for _, elem := range val {
+			v.validateValue(path, mp, parentDynamic, elem)
+		}
+		return
+	}
+
+	if mp.Children != nil || isObjectFieldType(mp.FieldType) {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			v.addError(path, "expected an object, got %s", jsonTypeName(value))
+			return
+		}
+		v.validateObject(path+".", mp.Children, &mp, isDynamicObject(mp, parentDynamic), obj)
+		return
+	}
+
+	v.validateScalar(path, mp, value)
+	for _, field := range mp.Fields {
+		v.validateValue(path+"."+field.FieldName, field, false, value)
+	}
+}
+
+func (v *documentValidator) validateScalar(path string, mp MappingProperty, value interface{}) {
+	fieldType := mp.FieldType
+	_, isInteger := integerFieldRanges[fieldType]
+	maxFloat, isFloat := floatFieldMaxValues[fieldType]
+	mismatch := func() {
+		v.addError(path, "%s value cannot be indexed into a field of type %q", jsonTypeName(value), fieldType)
+	}
+
+	switch {
+	case fieldType == "flat_object":
+		if _, ok := value.(map[string]interface{}); !ok {
+			mismatch()
+		}
+	case stringFieldTypes[fieldType]:
+		switch value.(type) {
+		case string, json.Number, bool:
+		default:
+			mismatch()
+		}
+	case fieldType == "binary":
+		if _, ok := value.(string); !ok {
+			mismatch()
+		}
+	case fieldType == "boolean":
+		switch val := value.(type) {
+		case bool:
+		case string:
+			if val != "true" && val != "false" && val != "" {
+				v.addError(path, "value %q is not a boolean", val)
+			}
+		default:
+			mismatch()
+		}
+	case isInteger:
+		number, ok := value.(json.Number)
+		if !ok {
+			mismatch()
+			return
+		}
+		v.validateInteger(path, fieldType, number)
+	case isFloat:
+		number, ok := value.(json.Number)
+		if !ok {
+			mismatch()
+			return
+		}
+		if f, err := number.Float64(); err != nil || math.Abs(f) > maxFloat {
+			v.addError(path, "value %s is out of the range of type %q", number, fieldType)
+		}
+	case fieldType == "date" || fieldType == "date_nanos":
+		format := defaultDateFormat
+		if mp.FieldFormat != nil && *mp.FieldFormat != "" {
+			format = *mp.FieldFormat
+		}
+		switch val := value.(type) {
+		case string:
+			if !dateMatchesFormat(val, format) {
+				v.addError(path, "value %q does not match date format %q", val, format)
+			}
+		case json.Number:
+			if !dateMatchesFormat(val.String(), format) {
+				v.addError(path, "value %s does not match date format %q", val, format)
+			}
+		default:
+			mismatch()
+		}
+	}
+}
+
+func (v *documentValidator) validateInteger(path, fieldType string, number json.Number) {
+	f, _, err := big.ParseFloat(number.String(), 10, 256, big.ToNearestEven)
+	if err != nil {
+		v.addError(path, "value %s is out of the range of type %q", number, fieldType)
+		return
+	}
+	if !f.IsInt() {
+		v.addError(path, "value %s is not an integer, as type %q requires", number, fieldType)
+		return
+	}
+	i, _ := f.Int(nil)
+	bounds := integerFieldRanges[fieldType]
+	if i.Cmp(bounds[0]) < 0 || i.Cmp(bounds[1]) > 0 {
+		v.addError(path, "value %s is out of the range of type %q", number, fieldType)
+	}
+}
+
+// dateMatchesFormat tells whether a date value parses under format. It is true if the value might be of an
+// alternative of format that is not registered.
+func dateMatchesFormat(value, format string) bool {
+	_, err := ParseDate(value, format)
+	return err == nil || errors.Is(err, ErrUnknownDateFormat)
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
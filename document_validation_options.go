@@ -0,0 +1,29 @@
+package opensearchutil
+
+type DocumentValidationOption interface {
+	apply(*documentValidationOptionContainer)
+}
+
+type documentValidationOptionContainer struct {
+	rootDynamicSet bool
+	rootDynamic    *string
+}
+
+type documentRootDynamicOption struct {
+	dynamic *string
+}
+
+func (c documentRootDynamicOption) apply(opts *documentValidationOptionContainer) {
+	opts.rootDynamicSet = true
+	opts.rootDynamic = c.dynamic
+}
+
+// WithDocumentRootDynamic sets the "dynamic" setting of the root mapping, e.g. ParsedIndex.Dynamic of the index. nil
+// means the mapping has none, which OpenSearch takes as "true". Unless it is "strict", ValidateDocument does not report
+// unknown fields at the root and under objects without a "dynamic" of their own. Without this option, the root is
+// taken as strict, the same as with WithRootDynamic of DiffMappingProperties.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithDocumentRootDynamic(dynamic *string) DocumentValidationOption {
+	return documentRootDynamicOption{dynamic: dynamic}
+}
@@ -0,0 +1,185 @@
+package opensearchutil
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func documentValidationTestProperties() []MappingProperty {
+	return []MappingProperty{
+		{
+			FieldName: "title",
+			FieldType: "text",
+			Fields:    []MappingProperty{{FieldName: "length", FieldType: "token_count"}},
+		},
+		{FieldName: "views", FieldType: "long"},
+		{FieldName: "rank", FieldType: "byte"},
+		{FieldName: "score", FieldType: "half_float"},
+		{FieldName: "active", FieldType: "boolean"},
+		{FieldName: "created_at", FieldType: "date", FieldFormat: MakePtr("basic_date")},
+		{FieldName: "updated_at", FieldType: "date"},
+		{FieldName: "synced_at", FieldType: "date", FieldFormat: MakePtr("basic_date||epoch_second")},
+		{
+			FieldName: "company",
+			FieldType: fieldTypeObject,
+			Children: []MappingProperty{
+				{FieldName: "name", FieldType: "keyword"},
+				{FieldName: "employees", FieldType: "integer"},
+			},
+		},
+		{
+			FieldName: "lines",
+			FieldType: fieldTypeNested,
+			Children:  []MappingProperty{{FieldName: "quantity", FieldType: "short"}},
+		},
+		{FieldName: "labels", FieldType: fieldTypeObject, Dynamic: MakePtr("true")},
+		{
+			FieldName:       "counts",
+			FieldType:       fieldTypeObject,
+			Dynamic:         MakePtr("true"),
+			DynamicTemplate: &MappingProperty{FieldName: "counts", FieldType: "integer"},
+		},
+		{FieldName: "attrs", FieldType: "flat_object"},
+	}
+}
+
+func TestValidateDocument_AcceptsValidDocument(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	doc := `{
+		"title": "Shoes",
+		"views": 9223372036854775807,
+		"rank": -128,
+		"score": 1.5,
+		"active": "true",
+		"created_at": "20240131",
+		"updated_at": "2024-01-31T10:00:00.123Z",
+		"synced_at": 1706695200,
+		"company": {"name": 42, "employees": null},
+		"company.employees": 10,
+		"lines": [{"quantity": 1}, {"quantity": 2}],
+		"labels": {"color": "red"},
+		"counts": {"a": 1, "b": [2, 3]},
+		"attrs": {"size": {"eu": 42}}
+	}`
+	g.Expect(ValidateDocument(documentValidationTestProperties(), []byte(doc))).To(gomega.Succeed())
+}
+
+func TestValidateDocument_ReportsAllProblems(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	doc := `{
+		"title": {"en": "Shoes"},
+		"views": "10",
+		"rank": 128,
+		"score": 70000,
+		"active": "yes",
+		"created_at": "2024-01-31",
+		"updated_at": "31/01/2024",
+		"synced_at": true,
+		"company": {"name": "Acme", "employees": 1.5, "country": "LT"},
+		"lines": [{"quantity": 1}, {"quantity": 40000}],
+		"counts": {"a": "many"},
+		"attrs": "none",
+		"color": "red"
+	}`
+
+	err := ValidateDocument(documentValidationTestProperties(), []byte(doc))
+	var fieldErrs FieldErrors
+	g.Expect(errors.As(err, &fieldErrs)).To(gomega.BeTrue())
+	g.Expect(fieldErrs).To(gomega.Equal(FieldErrors{
+		{Path: "active", Reason: `value "yes" is not a boolean`},
+		{Path: "attrs", Reason: `string value cannot be indexed into a field of type "flat_object"`},
+		{Path: "color", Reason: "field is not in the mapping"},
+		{Path: "company.country", Reason: "field is not in the mapping"},
+		{Path: "company.employees", Reason: `value 1.5 is not an integer, as type "integer" requires`},
+		{Path: "counts.a", Reason: `string value cannot be indexed into a field of type "integer"`},
+		{Path: "created_at", Reason: `value "2024-01-31" does not match date format "basic_date"`},
+		{Path: "lines.quantity", Reason: `value 40000 is out of the range of type "short"`},
+		{Path: "rank", Reason: `value 128 is out of the range of type "byte"`},
+		{Path: "score", Reason: `value 70000 is out of the range of type "half_float"`},
+		{Path: "synced_at", Reason: `boolean value cannot be indexed into a field of type "date"`},
+		{Path: "title", Reason: `object value cannot be indexed into a field of type "text"`},
+		{
+			Path:   "updated_at",
+			Reason: `value "31/01/2024" does not match date format "strict_date_optional_time||epoch_millis"`,
+		},
+		{Path: "views", Reason: `string value cannot be indexed into a field of type "long"`},
+	}))
+}
+
+func TestValidateDocument_UnknownFields(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	doc := []byte(`{"title": "Shoes", "color": "red", "company": {"name": "Acme", "country": "LT"}}`)
+	mps := documentValidationTestProperties()
+	strictErr := FieldErrors{
+		{Path: "color", Reason: "field is not in the mapping"},
+		{Path: "company.country", Reason: "field is not in the mapping"},
+	}
+
+	// Without options the root is strict, and objects without a "dynamic" of their own inherit it
+	g.Expect(ValidateDocument(mps, map[string]interface{}{"zz": 1})).
+		To(gomega.MatchError(FieldErrors{{Path: "zz", Reason: "field is not in the mapping"}}))
+	g.Expect(ValidateDocument(mps, doc)).To(gomega.MatchError(strictErr))
+	g.Expect(ValidateDocument(mps, doc, WithDocumentRootDynamic(MakePtr("strict")))).To(gomega.MatchError(strictErr))
+
+	// OpenSearch adds unknown fields to the mapping of a root without "dynamic" or with a non-strict one
+	g.Expect(ValidateDocument(mps, doc, WithDocumentRootDynamic(nil))).To(gomega.Succeed())
+	g.Expect(ValidateDocument(mps, doc, WithDocumentRootDynamic(MakePtr("false")))).To(gomega.Succeed())
+
+	// Objects with a "dynamic" of their own do not inherit the root setting
+	mps[8].Dynamic = MakePtr("strict")
+	g.Expect(ValidateDocument(mps, doc, WithDocumentRootDynamic(nil))).To(gomega.MatchError(FieldErrors{
+		{Path: "company.country", Reason: "field is not in the mapping"},
+	}))
+	mps[8].Dynamic = MakePtr("true")
+	g.Expect(ValidateDocument(mps, doc)).To(gomega.MatchError(FieldErrors{
+		{Path: "color", Reason: "field is not in the mapping"},
+	}))
+}
+
+func TestValidateDocument_GoValue(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type product struct {
+		Title     string        `json:"title"`
+		Views     int64         `json:"views"`
+		CreatedAt TimeBasicDate `json:"created_at"`
+		Comment   string        `json:"comment"`
+	}
+	mps := documentValidationTestProperties()
+	createdAt := TimeBasicDate(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))
+
+	err := ValidateDocument(mps, product{Title: "Shoes", Views: 1, CreatedAt: createdAt, Comment: "new"})
+	g.Expect(err).To(gomega.MatchError(FieldErrors{{Path: "comment", Reason: "field is not in the mapping"}}))
+
+	err = ValidateDocument(mps, &map[string]interface{}{"title": "Shoes", "views": uint64(1) << 63})
+	g.Expect(err).To(gomega.MatchError(`views: value 9223372036854775808 is out of the range of type "long"`))
+}
+
+func TestValidateDocument_BuiltMapping(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type order struct {
+		ID       string        `json:"id" opensearch:"type:keyword"`
+		Quantity int64         `json:"quantity"`
+		Created  TimeBasicDate `json:"created"`
+	}
+	mps, err := NewMappingPropertiesBuilder(WithJsonTagFieldNames()).BuildMappingProperties(order{})
+	g.Expect(err).To(gomega.BeNil())
+
+	// Quantity is mapped as "integer" by default, which cannot hold every int64
+	err = ValidateDocument(mps, order{ID: "1", Quantity: 1 << 40})
+	g.Expect(err).To(gomega.MatchError(`quantity: value 1099511627776 is out of the range of type "integer"`))
+}
+
+func TestValidateDocument_InvalidDocument(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(ValidateDocument(nil, []byte(`[1]`))).To(gomega.MatchError("a document must be a JSON object, got array"))
+	g.Expect(ValidateDocument(nil, []byte(`{`))).NotTo(gomega.Succeed())
+}
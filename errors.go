@@ -2,6 +2,118 @@ package opensearchutil
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 )
 
-var ErrGotBuiltInTimeField = errors.New(`time.Time fields cannot be used, use Time* types or custom types that implement encoding.TextMarshaler and opensearchutil.OpenSearchTime and marshall into OpenSearch date formats`)
+var (
+	ErrGotBuiltInTimeField = errors.New(`time.Time fields cannot be used, use Time* types or custom types that implement encoding.TextMarshaler and opensearchutil.OpenSearchTime and marshall into OpenSearch date formats`)
+	ErrNoIndexPatterns     = errors.New("an index template requires at least one index pattern")
+	ErrEmptyAliasActions   = errors.New("at least one alias action is required")
+	ErrEmptyAliasName      = errors.New("alias name is required")
+	ErrUnknownDateFormat   = errors.New("unknown date format")
+	ErrBuiltInDateFormat   = errors.New("built-in date formats cannot be replaced")
+)
+
+// InvalidInputError is returned by MappingPropertiesBuilder when it is given something other than a struct, a pointer
+// to a struct, or a reflect.Type of either.
+type InvalidInputError struct {
+	Type reflect.Type // nil if the input was nil
+}
+
+func (e *InvalidInputError) Error() string {
+	if e.Type == nil {
+		return "cannot build mapping properties of nil, a struct is required"
+	}
+	return fmt.Sprintf("cannot build mapping properties of %s (kind %s), a struct is required", e.Type, e.Type.Kind())
+}
+
+// FieldError is a problem with a single field of a mapping.
+type FieldError struct {
+	Path   string // Dotted path of the field, e.g. "company.name"
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Reason
+}
+
+// FieldErrors aggregates the problems found in multiple fields. The individual errors can be reached with errors.Is
+// and errors.As, which match any of them, or by ranging over FieldErrors.
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is makes errors.Is match the individual errors.
+func (e FieldErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As makes errors.As find the first individual error that matches target.
+func (e FieldErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildError is a problem with a single struct field found by MappingPropertiesBuilder.
+type BuildError struct {
+	GoPath string // Path of the field in Go types, e.g. "Order.Lines[].Product.Attrs"
+	Path   string // Dotted path of the resulting property, e.g. "lines.product.attrs"
+	Err    error  // The reason
+}
+
+func (e *BuildError) Error() string {
+	return fmt.Sprintf("%s (%s): %s", e.GoPath, e.Path, e.Err)
+}
+
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// BuildErrors aggregates the problems of multiple fields, see CollectAllErrors. The individual errors can be reached
+// with errors.Is and errors.As, which match any of them, or by ranging over BuildErrors.
+type BuildErrors []*BuildError
+
+func (e BuildErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is makes errors.Is match the individual errors and their reasons.
+func (e BuildErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As makes errors.As find the first individual error, or reason, that matches target.
+func (e BuildErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package opensearchutil
+
+import (
+	"reflect"
+	"strings"
+)
+
+// fieldTags holds the options of the opensearch tag of a struct field, parsed once per field.
+type fieldTags struct {
+	fieldType       string
+	format          string
+	analyzer        string
+	searchAnalyzer  string
+	normalizer      string
+	ignoreAbove     string
+	scalingFactor   string
+	copyTo          string
+	indexPrefixes   string
+	includeInParent string
+	includeInRoot   string
+	mapStrategy     string
+	flatten         string
+	id              bool
+
+	// fields are the multi-field definitions in the order given, keyed by the multi-field name
+	fields []tagOption
+}
+
+// parseFieldTags parses the opensearch tag of a field. When an option is given more than once, the first value wins,
+// the same as with getTagOptionValue.
+func parseFieldTags(field reflect.StructField) fieldTags {
+	var tags fieldTags
+	targets := map[string]*string{
+		tagOptionType:            &tags.fieldType,
+		tagOptionFormat:          &tags.format,
+		tagOptionAnalyzer:        &tags.analyzer,
+		tagOptionSearchAnalyzer:  &tags.searchAnalyzer,
+		tagOptionNormalizer:      &tags.normalizer,
+		tagOptionIgnoreAbove:     &tags.ignoreAbove,
+		tagOptionScalingFactor:   &tags.scalingFactor,
+		tagOptionCopyTo:          &tags.copyTo,
+		tagOptionIndexPrefixes:   &tags.indexPrefixes,
+		tagOptionIncludeInParent: &tags.includeInParent,
+		tagOptionIncludeInRoot:   &tags.includeInRoot,
+		tagOptionMap:             &tags.mapStrategy,
+		tagOptionFlatten:         &tags.flatten,
+	}
+	seen := make(map[string]bool, len(targets))
+	for _, opt := range getTagOptions(field, tagKey) {
+		if opt.key == tagFlagId {
+			tags.id = true
+			continue
+		}
+		if strings.HasPrefix(opt.key, tagOptionFieldsPrefix) {
+			tags.fields = append(tags.fields, tagOption{
+				key: strings.TrimPrefix(opt.key, tagOptionFieldsPrefix),
+				val: opt.val,
+			})
+			continue
+		}
+		if target, ok := targets[opt.key]; ok && !seen[opt.key] {
+			seen[opt.key] = true
+			*target = opt.val
+		}
+	}
+	return tags
+}
@@ -6,6 +6,15 @@ type IndexGenerationOption interface {
 
 type indexGenerationOptionContainer struct {
 	strictMapping bool
+	aliases       []Alias
+}
+
+func newIndexGenerationOptionContainer(options []IndexGenerationOption) indexGenerationOptionContainer {
+	optContainer := indexGenerationOptionContainer{}
+	for _, o := range options {
+		o.apply(&optContainer)
+	}
+	return optContainer
 }
 
 // Strict mapping
@@ -20,3 +29,17 @@ func (c strictMappingOption) apply(opts *indexGenerationOptionContainer) {
 func WithStrictMapping(strictMapping bool) IndexGenerationOption {
 	return strictMappingOption(strictMapping)
 }
+
+// Aliases
+
+type aliasesOption []Alias
+
+func (c aliasesOption) apply(opts *indexGenerationOptionContainer) {
+	opts.aliases = append(opts.aliases, c...)
+}
+
+// WithAliases adds "aliases" to the index or to the template of an index template. Every alias needs a name, the
+// generation fails with ErrEmptyAliasName otherwise.
+func WithAliases(aliases ...Alias) IndexGenerationOption {
+	return aliasesOption(aliases)
+}
@@ -1,6 +1,7 @@
 package opensearchutil
 
 import (
+	"bytes"
 	_ "embed"
 	"encoding/json"
 
@@ -13,19 +14,28 @@ type IndexGenerator struct {
 
 type (
 	indexDoc struct {
-		Mappings parentNode     `json:"mappings"`
-		Settings *IndexSettings `json:"settings,omitempty"`
+		Aliases  map[string]aliasDoc `json:"aliases,omitempty"`
+		Mappings parentNode          `json:"mappings"`
+		Settings *IndexSettings      `json:"settings,omitempty"`
 	}
 	propertiesDoc struct {
-		// Property maps from a property name to another parentNode or to a leafNode
-		Properties map[string]interface{} `json:"properties"`
+		DynamicTemplates []map[string]dynamicTemplate `json:"dynamic_templates,omitempty"`
+
+		Properties nodeProperties `json:"properties"`
 	}
 	parentNode struct {
-		// Dynamic applies to the root mapping and can have a value "strict"
+		// Type is empty for plain objects, or "nested" for nested fields
+		Type            string `json:"type,omitempty"`
+		IncludeInParent *bool  `json:"include_in_parent,omitempty"`
+		IncludeInRoot   *bool  `json:"include_in_root,omitempty"`
+
+		// Dynamic can have a value "strict" on the root mapping, or "true" on dynamic objects
 		Dynamic *string `json:"dynamic,omitempty"`
 
-		// Property maps from a property name to another parentNode or to a leafNode
-		Properties map[string]interface{} `json:"properties"`
+		// DynamicTemplates applies to the root mapping, it maps from a template name to the template
+		DynamicTemplates []map[string]dynamicTemplate `json:"dynamic_templates,omitempty"`
+
+		Properties nodeProperties `json:"properties"`
 	}
 	leafNode struct {
 		Type           string             `json:"type"`
@@ -33,7 +43,24 @@ type (
 		IndexPrefixes  *map[string]string `json:"index_prefixes,omitempty"`
 		Analyzer       *string            `json:"analyzer,omitempty"`
 		SearchAnalyzer *string            `json:"search_analyzer,omitempty"`
+		Normalizer     *string            `json:"normalizer,omitempty"`
+		IgnoreAbove    *uint32            `json:"ignore_above,omitempty"`
+		ScalingFactor  *float64           `json:"scaling_factor,omitempty"`
 		CopyTo         []string           `json:"copy_to,omitempty"`
+
+		// Fields are multi-fields, each a leafNode
+		Fields nodeProperties `json:"fields,omitempty"`
+	}
+	// nodeProperties are named parentNodes or leafNodes. They are marshalled into a JSON object in the order of the
+	// mapping properties they are built from, which is the order of declaration of struct fields.
+	nodeProperties []namedNode
+	namedNode      struct {
+		name string
+		node interface{}
+	}
+	dynamicTemplate struct {
+		PathMatch string   `json:"path_match"`
+		Mapping   leafNode `json:"mapping"`
 	}
 )
 
@@ -55,20 +82,14 @@ func (g *IndexGenerator) GenerateIndexJson(
 	settings *IndexSettings,
 	options ...IndexGenerationOption,
 ) ([]byte, error) {
-	optContainer := indexGenerationOptionContainer{}
-	for _, o := range options {
-		o.apply(&optContainer)
-	}
-	var dynamic *string
-	if optContainer.strictMapping {
-		dynamic = MakePtr("strict")
+	optContainer := newIndexGenerationOptionContainer(options)
+	aliases, err := buildAliases(optContainer.aliases)
+	if err != nil {
+		return nil, errors.Wrapf(err, "buildAliases")
 	}
-
 	jsonBytes, err := json.Marshal(indexDoc{
-		Mappings: parentNode{
-			Dynamic:    dynamic,
-			Properties: g.buildProperties(mappingProperties),
-		},
+		Aliases:  aliases,
+		Mappings: g.buildMappings(mappingProperties, optContainer),
 		Settings: settings,
 	})
 	if err != nil {
@@ -87,7 +108,8 @@ func (g *IndexGenerator) GenerateIndexJson(
 // update an index mapping.
 func (g *IndexGenerator) GenerateMappingsJson(mappingProperties []MappingProperty) ([]byte, error) {
 	jsonBytes, err := json.Marshal(propertiesDoc{
-		Properties: g.buildProperties(mappingProperties),
+		DynamicTemplates: g.buildDynamicTemplates(mappingProperties, ""),
+		Properties:       g.buildProperties(mappingProperties),
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "json.Marshal")
@@ -100,22 +122,101 @@ func (g *IndexGenerator) GenerateMappingsJson(mappingProperties []MappingPropert
 	return formattedJson, nil
 }
 
-func (g *IndexGenerator) buildProperties(mappingProperties []MappingProperty) map[string]interface{} {
-	m := make(map[string]interface{}, len(mappingProperties))
+// buildMappings builds the root mapping node, with "dynamic" and "dynamic_templates" set as needed.
+func (g *IndexGenerator) buildMappings(
+	mappingProperties []MappingProperty,
+	optContainer indexGenerationOptionContainer,
+) parentNode {
+	var dynamic *string
+	if optContainer.strictMapping {
+		dynamic = MakePtr("strict")
+	}
+
+	return parentNode{
+		Dynamic:          dynamic,
+		DynamicTemplates: g.buildDynamicTemplates(mappingProperties, ""),
+		Properties:       g.buildProperties(mappingProperties),
+	}
+}
+
+func (g *IndexGenerator) buildProperties(mappingProperties []MappingProperty) nodeProperties {
+	props := make(nodeProperties, 0, len(mappingProperties))
 	for _, mp := range mappingProperties {
-		if mp.Children == nil {
-			node := leafNode{
-				Type:           mp.FieldType,
-				Format:         mp.FieldFormat,
-				Analyzer:       mp.Analyzer,
-				SearchAnalyzer: mp.SearchAnalyzer,
-				CopyTo:         mp.CopyTo,
-				IndexPrefixes:  mp.IndexPrefixes,
-			}
-			m[mp.FieldName] = node
+		if mp.Children == nil && !isObjectFieldType(mp.FieldType) {
+			props = append(props, namedNode{name: mp.FieldName, node: g.buildLeafNode(mp)})
 		} else {
-			m[mp.FieldName] = parentNode{Properties: g.buildProperties(mp.Children)}
+			props = append(props, namedNode{name: mp.FieldName, node: parentNode{
+				Type:            mp.FieldType,
+				IncludeInParent: mp.IncludeInParent,
+				IncludeInRoot:   mp.IncludeInRoot,
+				Dynamic:         mp.Dynamic,
+				Properties:      g.buildProperties(mp.Children),
+			}})
+		}
+	}
+	return props
+}
+
+func (g *IndexGenerator) buildLeafNode(mp MappingProperty) leafNode {
+	node := leafNode{
+		Type:           mp.FieldType,
+		Format:         mp.FieldFormat,
+		Analyzer:       mp.Analyzer,
+		SearchAnalyzer: mp.SearchAnalyzer,
+		Normalizer:     mp.Normalizer,
+		IgnoreAbove:    mp.IgnoreAbove,
+		ScalingFactor:  mp.ScalingFactor,
+		CopyTo:         mp.CopyTo,
+		IndexPrefixes:  mp.IndexPrefixes,
+	}
+	if len(mp.Fields) > 0 {
+		node.Fields = g.buildProperties(mp.Fields)
+	}
+	return node
+}
+
+// buildDynamicTemplates collects the dynamic templates of map fields in the tree. Each template is named after the
+// dotted path of its field and matches all fields under it.
+func (g *IndexGenerator) buildDynamicTemplates(
+	mappingProperties []MappingProperty,
+	pathPrefix string,
+) []map[string]dynamicTemplate {
+	var templates []map[string]dynamicTemplate
+	for _, mp := range mappingProperties {
+		path := pathPrefix + mp.FieldName
+		if mp.DynamicTemplate != nil {
+			templates = append(templates, map[string]dynamicTemplate{
+				path: {
+					PathMatch: path + ".*",
+					Mapping:   g.buildLeafNode(*mp.DynamicTemplate),
+				},
+			})
+		}
+		templates = append(templates, g.buildDynamicTemplates(mp.Children, path+".")...)
+	}
+	return templates
+}
+
+// MarshalJSON marshals the properties into a JSON object, keeping their order.
+func (p nodeProperties) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, prop := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(prop.name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "json.Marshal")
+		}
+		node, err := json.Marshal(prop.node)
+		if err != nil {
+			return nil, errors.Wrapf(err, "json.Marshal")
 		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(node)
 	}
-	return m
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
 }
@@ -265,6 +265,265 @@ func TestIndexGenerator_GenerateIndexJson_addsSearchAnalyzerAndCopyTo(t *testing
 }`))
 }
 
+func TestIndexGenerator_GenerateIndexJson_addsNestedType(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mappingProperties := []MappingProperty{
+		{
+			FieldName:       "addresses",
+			FieldType:       "nested",
+			IncludeInParent: MakePtr(true),
+			IncludeInRoot:   MakePtr(false),
+			Children: []MappingProperty{
+				{
+					FieldName: "city",
+					FieldType: "keyword",
+				},
+			},
+		},
+	}
+
+	resultJson, err := NewIndexGenerator().GenerateIndexJson(mappingProperties, nil)
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "mappings": {
+      "properties": {
+         "addresses": {
+            "type": "nested",
+            "include_in_parent": true,
+            "include_in_root": false,
+            "properties": {
+               "city": {
+                  "type": "keyword"
+               }
+            }
+         }
+      }
+   }
+}`))
+}
+
+func TestIndexGenerator_GenerateIndexJson_addsMultiFields(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mappingProperties := []MappingProperty{
+		{
+			FieldName: "name",
+			FieldType: "text",
+			Fields: []MappingProperty{
+				{
+					FieldName:   "raw",
+					FieldType:   "keyword",
+					Normalizer:  MakePtr("lowercase"),
+					IgnoreAbove: MakePtr(uint32(256)),
+				},
+				{
+					FieldName: "english",
+					FieldType: "text",
+					Analyzer:  MakePtr("english"),
+				},
+			},
+		},
+	}
+
+	resultJson, err := NewIndexGenerator().GenerateIndexJson(mappingProperties, nil)
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "mappings": {
+      "properties": {
+         "name": {
+            "type": "text",
+            "fields": {
+               "raw": {
+                  "type": "keyword",
+                  "normalizer": "lowercase",
+                  "ignore_above": 256
+               },
+               "english": {
+                  "type": "text",
+                  "analyzer": "english"
+               }
+            }
+         }
+      }
+   }
+}`))
+}
+
+func TestIndexGenerator_GenerateIndexJson_addsScalingFactor(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type product struct {
+		Price float64 `opensearch:"type:scaled_float,scaling_factor:100"`
+	}
+	mappingProperties, err := NewMappingPropertiesBuilder().BuildMappingProperties(product{})
+	g.Expect(err).To(gomega.BeNil())
+
+	resultJson, err := NewIndexGenerator().GenerateIndexJson(mappingProperties, nil)
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "mappings": {
+      "properties": {
+         "price": {
+            "type": "scaled_float",
+            "scaling_factor": 100
+         }
+      }
+   }
+}`))
+
+	type invalid struct {
+		Price float64 `opensearch:"type:scaled_float,scaling_factor:many"`
+	}
+	_, err = NewMappingPropertiesBuilder().BuildMappingProperties(invalid{})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("strconv.ParseFloat scaling_factor")))
+}
+
+func TestIndexGenerator_GenerateIndexJson_addsDynamicObjectsAndTemplates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mappingProperties := []MappingProperty{
+		{
+			FieldName: "extra",
+			FieldType: "object",
+			Dynamic:   MakePtr("true"),
+		},
+		{
+			FieldName: "meta",
+			Children: []MappingProperty{
+				{
+					FieldName:       "labels",
+					FieldType:       "object",
+					Dynamic:         MakePtr("true"),
+					DynamicTemplate: &MappingProperty{FieldName: "labels", FieldType: "keyword"},
+				},
+			},
+		},
+	}
+
+	resultJson, err := NewIndexGenerator().GenerateIndexJson(mappingProperties, nil, WithStrictMapping(true))
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "mappings": {
+      "dynamic": "strict",
+      "dynamic_templates": [
+         {
+            "meta.labels": {
+               "path_match": "meta.labels.*",
+               "mapping": {
+                  "type": "keyword"
+               }
+            }
+         }
+      ],
+      "properties": {
+         "extra": {
+            "type": "object",
+            "dynamic": "true",
+            "properties": {}
+         },
+         "meta": {
+            "properties": {
+               "labels": {
+                  "type": "object",
+                  "dynamic": "true",
+                  "properties": {}
+               }
+            }
+         }
+      }
+   }
+}`))
+
+	resultJson, err = NewIndexGenerator().GenerateMappingsJson(mappingProperties[1:])
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "dynamic_templates": [
+      {
+         "meta.labels": {
+            "path_match": "meta.labels.*",
+            "mapping": {
+               "type": "keyword"
+            }
+         }
+      }
+   ],
+   "properties": {
+      "meta": {
+         "properties": {
+            "labels": {
+               "type": "object",
+               "dynamic": "true",
+               "properties": {}
+            }
+         }
+      }
+   }
+}`))
+}
+
+func TestIndexGenerator_GenerateIndexJson_DeclarationOrder(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type address struct {
+		Street string
+		City   string `opensearch:"type:keyword"`
+	}
+	type person struct {
+		Name    string `opensearch:"fields.raw:type=keyword"`
+		Age     int
+		Address address
+	}
+
+	mappingProperties, err := NewMappingPropertiesBuilder().BuildMappingProperties(person{})
+	g.Expect(err).To(gomega.BeNil())
+
+	resultJson, err := NewIndexGenerator(
+		WithJsonFormatter(NewOrderedJsonFormatter(KeyOrderDeclaration, "  ")),
+	).GenerateIndexJson(mappingProperties, nil, WithStrictMapping(true))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(resultJson)).To(gomega.Equal(`{
+  "mappings": {
+    "dynamic": "strict",
+    "properties": {
+      "name": {
+        "type": "text",
+        "fields": {
+          "raw": {
+            "type": "keyword"
+          }
+        }
+      },
+      "age": {
+        "type": "integer"
+      },
+      "address": {
+        "properties": {
+          "street": {
+            "type": "text"
+          },
+          "city": {
+            "type": "keyword"
+          }
+        }
+      }
+    }
+  }
+}`))
+
+	resultJson, err = NewIndexGenerator(
+		WithJsonFormatter(NewCompactJsonFormatter(KeyOrderDeclaration)),
+	).GenerateMappingsJson(mappingProperties[:2])
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(resultJson)).To(gomega.Equal(
+		`{"properties":{"name":{"type":"text","fields":{"raw":{"type":"keyword"}}},"age":{"type":"integer"}}}`))
+}
+
 func makeJsonObj(jsonBytes []byte) (map[string]interface{}, error) {
 	var m map[string]interface{}
 	if err := json.Unmarshal(jsonBytes, &m); err != nil {
@@ -0,0 +1,63 @@
+package opensearchutil
+
+import (
+	"reflect"
+	"strings"
+)
+
+// staticIndexSettings are the settings, by their JSON names, that can only be set at index creation or on a closed
+// index. The other settings of IndexSettings are dynamic and can be updated with the update-settings API.
+var staticIndexSettings = map[string]bool{
+	"number_of_shards":                    true,
+	"number_of_routing_shards":            true,
+	"shard.check_on_startup":              true,
+	"codec":                               true,
+	"routing_partition_size":              true,
+	"soft_deletes.retention_lease.period": true,
+	"load_fixed_bitset_filters_eagerly":   true,
+	"hidden":                              true,
+	"analysis":                            true,
+}
+
+// DiffIndexSettings compares the settings set in desired with those in current, which can be nil. It returns the JSON
+// names of the static settings that differ, which cannot be changed on an open index, and the dynamic settings that
+// differ, or nil if there are none. Settings not set in desired are left as they are and not compared. Analysis
+// settings are not compared either, as MappingParser does not parse them.
+func DiffIndexSettings(current *IndexSettings, desired *IndexSettings) ([]string, *IndexSettings) {
+	if desired == nil {
+		return nil, nil
+	}
+	if current == nil {
+		current = &IndexSettings{}
+	}
+
+	var staticChanges []string
+	dynamicChanges := &IndexSettings{}
+	hasDynamicChanges := false
+
+	currentVal := reflect.ValueOf(current).Elem()
+	desiredVal := reflect.ValueOf(desired).Elem()
+	changesVal := reflect.ValueOf(dynamicChanges).Elem()
+	for i := 0; i < desiredVal.NumField(); i++ {
+		name, _, _ := strings.Cut(desiredVal.Type().Field(i).Tag.Get("json"), ",")
+		desiredField := desiredVal.Field(i)
+		if desiredField.IsNil() || name == "analysis" {
+			continue
+		}
+		if currentField := currentVal.Field(i); !currentField.IsNil() &&
+			reflect.DeepEqual(currentField.Interface(), desiredField.Interface()) {
+			continue
+		}
+		if staticIndexSettings[name] {
+			staticChanges = append(staticChanges, name)
+			continue
+		}
+		changesVal.Field(i).Set(desiredField)
+		hasDynamicChanges = true
+	}
+
+	if !hasDynamicChanges {
+		return staticChanges, nil
+	}
+	return staticChanges, dynamicChanges
+}
@@ -0,0 +1,44 @@
+package opensearchutil
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestDiffIndexSettings(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	current := &IndexSettings{
+		NumberOfShards:   MakePtr(uint16(1)),
+		NumberOfReplicas: MakePtr(uint16(1)),
+		RefreshInterval:  MakePtr("1s"),
+		Codec:            MakePtr("default"),
+	}
+	desired := &IndexSettings{
+		NumberOfShards:   MakePtr(uint16(3)),
+		NumberOfReplicas: MakePtr(uint16(2)),
+		RefreshInterval:  MakePtr("1s"),
+		MaxResultWindow:  MakePtr(uint64(20000)),
+		Analysis:         &Analysis{Analyzer: map[string]Analyzer{"a": {Type: "standard"}}},
+	}
+
+	staticChanges, dynamicChanges := DiffIndexSettings(current, desired)
+	g.Expect(staticChanges).To(gomega.Equal([]string{"number_of_shards"}))
+	g.Expect(dynamicChanges).To(gomega.Equal(&IndexSettings{
+		NumberOfReplicas: MakePtr(uint16(2)),
+		MaxResultWindow:  MakePtr(uint64(20000)),
+	}))
+
+	staticChanges, dynamicChanges = DiffIndexSettings(current, &IndexSettings{RefreshInterval: MakePtr("1s")})
+	g.Expect(staticChanges).To(gomega.BeEmpty())
+	g.Expect(dynamicChanges).To(gomega.BeNil())
+
+	staticChanges, dynamicChanges = DiffIndexSettings(nil, &IndexSettings{Codec: MakePtr("best_compression")})
+	g.Expect(staticChanges).To(gomega.Equal([]string{"codec"}))
+	g.Expect(dynamicChanges).To(gomega.BeNil())
+
+	staticChanges, dynamicChanges = DiffIndexSettings(current, nil)
+	g.Expect(staticChanges).To(gomega.BeEmpty())
+	g.Expect(dynamicChanges).To(gomega.BeNil())
+}
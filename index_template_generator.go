@@ -0,0 +1,150 @@
+package opensearchutil
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// IndexTemplate holds the attributes of a composable index template other than its settings and mappings.
+// Refer to https://opensearch.org/docs/latest/im-plugin/index-templates/ for docs on each attribute.
+type IndexTemplate struct {
+	IndexPatterns []string
+	Priority      *int
+	Version       *int
+	ComposedOf    []string
+	Meta          map[string]interface{}
+
+	// DataStream makes the template create data streams rather than indices
+	DataStream *DataStream
+}
+
+// ComponentTemplate holds the attributes of a component template other than its settings and mappings.
+type ComponentTemplate struct {
+	Version *int
+	Meta    map[string]interface{}
+}
+
+// DataStream configures the data streams created from an index template.
+type DataStream struct {
+	// TimestampField defaults to "@timestamp" in OpenSearch
+	TimestampField *string
+}
+
+type (
+	indexTemplateDoc struct {
+		IndexPatterns []string               `json:"index_patterns"`
+		Template      *templateDoc           `json:"template,omitempty"`
+		Priority      *int                   `json:"priority,omitempty"`
+		Version       *int                   `json:"version,omitempty"`
+		ComposedOf    []string               `json:"composed_of,omitempty"`
+		Meta          map[string]interface{} `json:"_meta,omitempty"`
+		DataStream    *dataStreamDoc         `json:"data_stream,omitempty"`
+	}
+	componentTemplateDoc struct {
+		Template templateDoc            `json:"template"`
+		Version  *int                   `json:"version,omitempty"`
+		Meta     map[string]interface{} `json:"_meta,omitempty"`
+	}
+	templateDoc struct {
+		Aliases  map[string]aliasDoc `json:"aliases,omitempty"`
+		Mappings *parentNode         `json:"mappings,omitempty"`
+		Settings *IndexSettings      `json:"settings,omitempty"`
+	}
+	dataStreamDoc struct {
+		TimestampField *timestampFieldDoc `json:"timestamp_field,omitempty"`
+	}
+	timestampFieldDoc struct {
+		Name string `json:"name"`
+	}
+)
+
+// GenerateIndexTemplateJson generates a JSON document for the create-index-template API. The mappings and settings
+// go into "template" and apply to every index (e.g. rollover indices) created from the template. mappingProperties
+// can be empty for templates that get their mappings from component templates.
+func (g *IndexGenerator) GenerateIndexTemplateJson(
+	template IndexTemplate,
+	mappingProperties []MappingProperty,
+	settings *IndexSettings,
+	options ...IndexGenerationOption,
+) ([]byte, error) {
+	if len(template.IndexPatterns) == 0 {
+		return nil, ErrNoIndexPatterns
+	}
+
+	doc := indexTemplateDoc{
+		IndexPatterns: template.IndexPatterns,
+		Priority:      template.Priority,
+		Version:       template.Version,
+		ComposedOf:    template.ComposedOf,
+		Meta:          template.Meta,
+	}
+	t, err := g.buildTemplate(mappingProperties, settings, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "buildTemplate")
+	}
+	if t.Mappings != nil || t.Settings != nil || t.Aliases != nil {
+		doc.Template = &t
+	}
+	if template.DataStream != nil {
+		doc.DataStream = &dataStreamDoc{}
+		if template.DataStream.TimestampField != nil {
+			doc.DataStream.TimestampField = &timestampFieldDoc{Name: *template.DataStream.TimestampField}
+		}
+	}
+
+	return g.marshalTemplate(doc)
+}
+
+// GenerateComponentTemplateJson generates a JSON document for the create-component-template API, to be used in
+// IndexTemplate.ComposedOf.
+func (g *IndexGenerator) GenerateComponentTemplateJson(
+	template ComponentTemplate,
+	mappingProperties []MappingProperty,
+	settings *IndexSettings,
+	options ...IndexGenerationOption,
+) ([]byte, error) {
+	t, err := g.buildTemplate(mappingProperties, settings, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "buildTemplate")
+	}
+	return g.marshalTemplate(componentTemplateDoc{
+		Template: t,
+		Version:  template.Version,
+		Meta:     template.Meta,
+	})
+}
+
+func (g *IndexGenerator) buildTemplate(
+	mappingProperties []MappingProperty,
+	settings *IndexSettings,
+	options []IndexGenerationOption,
+) (templateDoc, error) {
+	optContainer := newIndexGenerationOptionContainer(options)
+	aliases, err := buildAliases(optContainer.aliases)
+	if err != nil {
+		return templateDoc{}, errors.Wrapf(err, "buildAliases")
+	}
+	t := templateDoc{
+		Aliases:  aliases,
+		Settings: settings,
+	}
+	mappings := g.buildMappings(mappingProperties, optContainer)
+	if len(mappingProperties) > 0 || mappings.Dynamic != nil {
+		t.Mappings = &mappings
+	}
+	return t, nil
+}
+
+func (g *IndexGenerator) marshalTemplate(doc interface{}) ([]byte, error) {
+	jsonBytes, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "json.Marshal")
+	}
+
+	formattedJson, err := g.optionContainer.jsonFormatter.FormatJson(jsonBytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "formatJson")
+	}
+	return formattedJson, nil
+}
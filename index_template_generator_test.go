@@ -0,0 +1,99 @@
+package opensearchutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestIndexGenerator_GenerateIndexTemplateJson(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	resultJson, err := NewIndexGenerator().GenerateIndexTemplateJson(
+		IndexTemplate{
+			IndexPatterns: []string{"logs-*"},
+			Priority:      MakePtr(100),
+			Version:       MakePtr(3),
+			ComposedOf:    []string{"logs-settings"},
+			Meta:          map[string]interface{}{"owner": "team-a"},
+			DataStream:    &DataStream{TimestampField: MakePtr("created_at")},
+		},
+		[]MappingProperty{
+			{FieldName: "message", FieldType: "text"},
+		},
+		&IndexSettings{NumberOfShards: MakePtr(uint16(1))},
+		WithStrictMapping(true),
+	)
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "index_patterns": ["logs-*"],
+   "priority": 100,
+   "version": 3,
+   "composed_of": ["logs-settings"],
+   "_meta": {"owner": "team-a"},
+   "data_stream": {"timestamp_field": {"name": "created_at"}},
+   "template": {
+      "settings": {"number_of_shards": 1},
+      "mappings": {
+         "dynamic": "strict",
+         "properties": {
+            "message": {"type": "text"}
+         }
+      }
+   }
+}`))
+}
+
+func TestIndexGenerator_GenerateIndexTemplateJson_OmitsEmptyTemplate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	resultJson, err := NewIndexGenerator().GenerateIndexTemplateJson(
+		IndexTemplate{
+			IndexPatterns: []string{"logs-*"},
+			ComposedOf:    []string{"logs-mappings", "logs-settings"},
+			DataStream:    &DataStream{},
+		},
+		nil,
+		nil,
+	)
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "index_patterns": ["logs-*"],
+   "composed_of": ["logs-mappings", "logs-settings"],
+   "data_stream": {}
+}`))
+}
+
+func TestIndexGenerator_GenerateIndexTemplateJson_RequiresIndexPatterns(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	_, err := NewIndexGenerator().GenerateIndexTemplateJson(IndexTemplate{}, nil, nil)
+	g.Expect(errors.Is(err, ErrNoIndexPatterns)).To(gomega.BeTrue())
+}
+
+func TestIndexGenerator_GenerateComponentTemplateJson(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	resultJson, err := NewIndexGenerator().GenerateComponentTemplateJson(
+		ComponentTemplate{Version: MakePtr(1)},
+		[]MappingProperty{
+			{FieldName: "created_at", FieldType: "date", FieldFormat: MakePtr("basic_date_time")},
+		},
+		nil,
+	)
+	g.Expect(err).To(gomega.BeNil())
+
+	assertJsonsEqual(g, resultJson, []byte(`{
+   "version": 1,
+   "template": {
+      "mappings": {
+         "properties": {
+            "created_at": {"type": "date", "format": "basic_date_time"}
+         }
+      }
+   }
+}`))
+}
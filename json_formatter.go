@@ -1,11 +1,30 @@
 package opensearchutil
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
+	"sort"
 
 	"github.com/pkg/errors"
 )
 
+// KeyOrder tells OrderedJsonFormatter how to order the keys of JSON objects.
+type KeyOrder string
+
+const (
+	// KeyOrderDeclaration keeps keys in the order they come in. Documents of IndexGenerator list properties in the
+	// order of declaration of struct fields, and the attributes of a property in a fixed order starting with "type".
+	KeyOrderDeclaration KeyOrder = "declaration"
+
+	// KeyOrderAlphabetical sorts keys alphabetically, like MarshalIndentJsonFormatter does.
+	KeyOrderAlphabetical KeyOrder = "alphabetical"
+
+	// KeyOrderTypeFirst sorts keys alphabetically, except for "type", which comes first.
+	KeyOrderTypeFirst KeyOrder = "type_first"
+)
+
+// MarshalIndentJsonFormatter indents JSON with three spaces and sorts the keys of objects alphabetically.
 type MarshalIndentJsonFormatter struct{}
 
 func NewMarshalIndentJsonFormatter() *MarshalIndentJsonFormatter {
@@ -24,3 +43,157 @@ func (f *MarshalIndentJsonFormatter) FormatJson(str []byte) ([]byte, error) {
 	}
 	return jsonBytes, nil
 }
+
+// OrderedJsonFormatter indents JSON, ordering the keys of objects by a KeyOrder. Unlike MarshalIndentJsonFormatter,
+// it keeps numbers as they are written. An empty indent makes it compact.
+type OrderedJsonFormatter struct {
+	keyOrder KeyOrder
+	indent   string
+}
+
+// NewOrderedJsonFormatter creates an OrderedJsonFormatter that indents with the given indent, e.g. "  ".
+//
+//goland:noinspection GoUnusedExportedFunction
+func NewOrderedJsonFormatter(keyOrder KeyOrder, indent string) *OrderedJsonFormatter {
+	return &OrderedJsonFormatter{keyOrder: keyOrder, indent: indent}
+}
+
+// NewCompactJsonFormatter creates an OrderedJsonFormatter that writes JSON without any whitespace, for sending
+// documents over the wire.
+//
+//goland:noinspection GoUnusedExportedFunction
+func NewCompactJsonFormatter(keyOrder KeyOrder) *OrderedJsonFormatter {
+	return &OrderedJsonFormatter{keyOrder: keyOrder}
+}
+
+func (f *OrderedJsonFormatter) FormatJson(str []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(str))
+	decoder.UseNumber()
+	value, err := decodeOrderedJsonValue(decoder)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decodeOrderedJsonValue")
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+
+	var buf bytes.Buffer
+	if err := f.write(&buf, value); err != nil {
+		return nil, errors.Wrapf(err, "write")
+	}
+	if f.indent == "" {
+		return buf.Bytes(), nil
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, buf.Bytes(), "", f.indent); err != nil {
+		return nil, errors.Wrapf(err, "json.Indent")
+	}
+	return indented.Bytes(), nil
+}
+
+// orderedJsonObject is a JSON object which keeps the order of its keys.
+type orderedJsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// decodeOrderedJsonValue decodes the next JSON value into an orderedJsonObject, a []interface{} or a scalar.
+func decodeOrderedJsonValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoder.Token")
+	}
+
+	switch token {
+	case json.Delim('{'):
+		obj := orderedJsonObject{values: make(map[string]interface{})}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, errors.Wrapf(err, "decoder.Token")
+			}
+			key := keyToken.(string)
+			value, err := decodeOrderedJsonValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := obj.values[key]; !ok {
+				obj.keys = append(obj.keys, key)
+			}
+			obj.values[key] = value
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, errors.Wrapf(err, "decoder.Token")
+		}
+		return obj, nil
+	case json.Delim('['):
+		arr := []interface{}{}
+		for decoder.More() {
+			value, err := decodeOrderedJsonValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, errors.Wrapf(err, "decoder.Token")
+		}
+		return arr, nil
+	default:
+		return token, nil
+	}
+}
+
+func (f *OrderedJsonFormatter) write(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case orderedJsonObject:
+		buf.WriteByte('{')
+		for i, key := range f.orderKeys(v.keys) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := f.write(buf, key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := f.write(buf, v.values[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := f.write(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		jsonBytes, err := json.Marshal(v)
+		if err != nil {
+			return errors.Wrapf(err, "json.Marshal")
+		}
+		buf.Write(jsonBytes)
+	}
+	return nil
+}
+
+func (f *OrderedJsonFormatter) orderKeys(keys []string) []string {
+	if f.keyOrder == KeyOrderDeclaration || f.keyOrder == "" {
+		return keys
+	}
+
+	sorted := append([]string{}, keys...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if f.keyOrder == KeyOrderTypeFirst && (sorted[i] == "type") != (sorted[j] == "type") {
+			return sorted[i] == "type"
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
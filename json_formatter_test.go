@@ -23,3 +23,91 @@ func TestMarshalIndentJsonFormatter_FormatJson(t *testing.T) {
 	g.Expect(err).To(gomega.BeNil())
 	g.Expect(string(res)).To(gomega.Equal(expectedJson))
 }
+
+func TestOrderedJsonFormatter_FormatJson(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	inputJson := `{"name": {"type": "text", "analyzer": "standard"}, "age": {"type": "integer"},
+		"ids": [3, 1.50, {"b": 1, "a": 2}], "empty": {}, "none": null}`
+
+	tests := []struct {
+		name      string
+		formatter *OrderedJsonFormatter
+		expected  string
+	}{
+		{
+			name:      "declaration",
+			formatter: NewOrderedJsonFormatter(KeyOrderDeclaration, "  "),
+			expected: `{
+  "name": {
+    "type": "text",
+    "analyzer": "standard"
+  },
+  "age": {
+    "type": "integer"
+  },
+  "ids": [
+    3,
+    1.50,
+    {
+      "b": 1,
+      "a": 2
+    }
+  ],
+  "empty": {},
+  "none": null
+}`,
+		},
+		{
+			name:      "alphabetical",
+			formatter: NewOrderedJsonFormatter(KeyOrderAlphabetical, "\t"),
+			expected: `{
+	"age": {
+		"type": "integer"
+	},
+	"empty": {},
+	"ids": [
+		3,
+		1.50,
+		{
+			"a": 2,
+			"b": 1
+		}
+	],
+	"name": {
+		"analyzer": "standard",
+		"type": "text"
+	},
+	"none": null
+}`,
+		},
+		{
+			name:      "type first, compact",
+			formatter: NewCompactJsonFormatter(KeyOrderTypeFirst),
+			expected: `{"age":{"type":"integer"},"empty":{},"ids":[3,1.50,{"a":2,"b":1}],` +
+				`"name":{"type":"text","analyzer":"standard"},"none":null}`,
+		},
+		{
+			name:      "declaration, compact",
+			formatter: NewCompactJsonFormatter(KeyOrderDeclaration),
+			expected: `{"name":{"type":"text","analyzer":"standard"},"age":{"type":"integer"},` +
+				`"ids":[3,1.50,{"b":1,"a":2}],"empty":{},"none":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := tt.formatter.FormatJson([]byte(inputJson))
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(string(res)).To(gomega.Equal(tt.expected))
+		})
+	}
+}
+
+func TestOrderedJsonFormatter_FormatJson_InvalidJson(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	for _, inputJson := range []string{`{"a": }`, `{"a": 1`, `{"a": 1} {}`, ``} {
+		_, err := NewCompactJsonFormatter(KeyOrderDeclaration).FormatJson([]byte(inputJson))
+		g.Expect(err).NotTo(gomega.BeNil(), inputJson)
+	}
+}
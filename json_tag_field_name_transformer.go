@@ -0,0 +1,52 @@
+package opensearchutil
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// JsonTagFieldNameTransformer names properties the same way encoding/json names the keys of a marshalled struct:
+// it uses the name from the `json` tag when present, skips fields tagged `json:"-"` and unexported fields, and
+// falls back to another FieldNameTransformer for fields without a tag name.
+type JsonTagFieldNameTransformer struct {
+	fallback FieldNameTransformer
+}
+
+// NewJsonTagFieldNameTransformer creates a JsonTagFieldNameTransformer. If fallback is nil, SnakeCaser is used.
+func NewJsonTagFieldNameTransformer(fallback FieldNameTransformer) *JsonTagFieldNameTransformer {
+	if fallback == nil {
+		fallback = NewSnakeCaser()
+	}
+	return &JsonTagFieldNameTransformer{fallback: fallback}
+}
+
+func (t JsonTagFieldNameTransformer) TransformFieldName(name string) (string, error) {
+	return t.fallback.TransformFieldName(name)
+}
+
+func (t JsonTagFieldNameTransformer) TransformStructField(field reflect.StructField) (string, bool, error) {
+	if !field.IsExported() {
+		return "", true, nil
+	}
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		name, err := t.fallback.TransformFieldName(field.Name)
+		if err != nil {
+			return "", false, errors.Wrapf(err, "TransformFieldName")
+		}
+		return name, false, nil
+	}
+	if tag == "-" {
+		return "", true, nil
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, false, nil
+	}
+	name, err := t.fallback.TransformFieldName(field.Name)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "TransformFieldName")
+	}
+	return name, false, nil
+}
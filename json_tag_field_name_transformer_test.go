@@ -0,0 +1,42 @@
+package opensearchutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestJsonTagFieldNameTransformer_TransformStructField(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type foo struct {
+		Plain     string
+		Renamed   string `json:"renamed_field"`
+		OmitEmpty string `json:",omitempty"`
+		Skipped   string `json:"-"`
+		Dash      string `json:"-,"`
+		private   string
+	}
+
+	transformer := NewJsonTagFieldNameTransformer(nil)
+	typ := reflect.TypeOf(foo{})
+
+	expectations := []struct {
+		name string
+		skip bool
+	}{
+		{name: "plain"},
+		{name: "renamed_field"},
+		{name: "omit_empty"},
+		{skip: true},
+		{name: "-"},
+		{skip: true},
+	}
+	for i, e := range expectations {
+		name, skip, err := transformer.TransformStructField(typ.Field(i))
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(name).To(gomega.Equal(e.name), typ.Field(i).Name)
+		g.Expect(skip).To(gomega.Equal(e.skip), typ.Field(i).Name)
+	}
+}
@@ -0,0 +1,319 @@
+package opensearchutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MappingChangeKind tells whether a field was added, removed or changed.
+type MappingChangeKind string
+
+const (
+	MappingChangeAdded   MappingChangeKind = "added"
+	MappingChangeRemoved MappingChangeKind = "removed"
+	MappingChangeChanged MappingChangeKind = "changed"
+)
+
+// MappingChange is a difference of a single field, or of a single attribute of a field, between two mappings.
+type MappingChange struct {
+	// Path is the dotted path of the field, e.g. "company.name", or "name.raw" for a multi-field
+	Path string            `json:"path"`
+	Kind MappingChangeKind `json:"kind"`
+
+	// Attribute is the changed mapping parameter, e.g. "type" or "analyzer", for changes of kind MappingChangeChanged
+	Attribute string `json:"attribute,omitempty"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+
+	// Breaking is true if the change cannot be applied to an existing index with the put-mapping API, and the index
+	// has to be reindexed instead.
+	Breaking bool `json:"breaking"`
+}
+
+// MappingDiff is the list of differences between a current (e.g. deployed) and a desired (e.g. generated) mapping.
+type MappingDiff struct {
+	Changes []MappingChange `json:"changes"`
+}
+
+// mappingAttribute is a mapping parameter compared by DiffMappingProperties.
+type mappingAttribute struct {
+	name string
+	get  func(MappingProperty) string
+	// updatable tells whether the parameter can be changed on an existing field with the put-mapping API
+	updatable bool
+}
+
+var mappingAttributes = []mappingAttribute{
+	{name: "type", get: func(p MappingProperty) string { return normalizedFieldType(p) }},
+	{name: "format", get: func(p MappingProperty) string { return stringOrEmpty(p.FieldFormat) }},
+	{name: "analyzer", get: func(p MappingProperty) string { return stringOrEmpty(p.Analyzer) }},
+	{
+		name:      "search_analyzer",
+		get:       func(p MappingProperty) string { return stringOrEmpty(p.SearchAnalyzer) },
+		updatable: true,
+	},
+	{name: "normalizer", get: func(p MappingProperty) string { return stringOrEmpty(p.Normalizer) }},
+	{
+		name: "ignore_above",
+		get: func(p MappingProperty) string {
+			if p.IgnoreAbove == nil {
+				return ""
+			}
+			return fmt.Sprint(*p.IgnoreAbove)
+		},
+		updatable: true,
+	},
+	{
+		name: "scaling_factor",
+		get: func(p MappingProperty) string {
+			if p.ScalingFactor == nil {
+				return ""
+			}
+			return strconv.FormatFloat(*p.ScalingFactor, 'f', -1, 64)
+		},
+	},
+	{name: "copy_to", get: func(p MappingProperty) string { return strings.Join(p.CopyTo, ",") }},
+	{
+		name: "index_prefixes",
+		get: func(p MappingProperty) string {
+			if p.IndexPrefixes == nil {
+				return ""
+			}
+			return formatStringMap(*p.IndexPrefixes)
+		},
+	},
+	{name: "include_in_parent", get: func(p MappingProperty) string { return boolOrEmpty(p.IncludeInParent) }},
+	{name: "include_in_root", get: func(p MappingProperty) string { return boolOrEmpty(p.IncludeInRoot) }},
+	{name: "dynamic", get: func(p MappingProperty) string { return stringOrEmpty(p.Dynamic) }, updatable: true},
+	{
+		name: "dynamic_template",
+		get: func(p MappingProperty) string {
+			if p.DynamicTemplate == nil {
+				return ""
+			}
+			return describeMappingProperty(*p.DynamicTemplate)
+		},
+		updatable: true,
+	},
+}
+
+// DiffMappingProperties compares the current mapping properties with the desired ones. New fields and new
+// multi-fields are compatible changes, as are changes of the parameters that OpenSearch allows to update
+// (search_analyzer, ignore_above, dynamic and dynamic templates). Removed fields and any other change (e.g. of type,
+// format or analyzer, or between an object and a nested field) are breaking.
+//
+// Fields that only the current mapping has under a desired object that is dynamic (with "dynamic" other than
+// "strict", inherited by objects without a "dynamic" of their own) or has a dynamic template are taken as added by
+// OpenSearch at ingest time, and are not reported. The same applies at the root if WithRootDynamic is given a
+// non-strict setting.
+func DiffMappingProperties(
+	current []MappingProperty,
+	desired []MappingProperty,
+	options ...MappingDiffOption,
+) *MappingDiff {
+	optContainer := mappingDiffOptionContainer{}
+	for _, o := range options {
+		o.apply(&optContainer)
+	}
+
+	diff := &MappingDiff{}
+	rootDynamic := isDynamicRoot(optContainer.rootDynamicSet, optContainer.rootDynamic)
+	diffProperties(diff, "", current, desired, rootDynamic)
+	sort.SliceStable(diff.Changes, func(i, j int) bool {
+		return diff.Changes[i].Path < diff.Changes[j].Path
+	})
+	return diff
+}
+
+// diffProperties compares the properties of an object. dynamic tells whether OpenSearch adds unknown fields to the
+// object, in which case fields that only the current properties have are not reported as removed.
+func diffProperties(
+	diff *MappingDiff,
+	pathPrefix string,
+	current []MappingProperty,
+	desired []MappingProperty,
+	dynamic bool,
+) {
+	currentByName := make(map[string]MappingProperty, len(current))
+	for _, p := range current {
+		currentByName[p.FieldName] = p
+	}
+	desiredByName := make(map[string]MappingProperty, len(desired))
+	for _, p := range desired {
+		desiredByName[p.FieldName] = p
+	}
+
+	for _, c := range current {
+		if _, ok := desiredByName[c.FieldName]; !ok && !dynamic {
+			diff.Changes = append(diff.Changes, MappingChange{
+				Path:     pathPrefix + c.FieldName,
+				Kind:     MappingChangeRemoved,
+				Old:      describeMappingProperty(c),
+				Breaking: true,
+			})
+		}
+	}
+	for _, d := range desired {
+		path := pathPrefix + d.FieldName
+		c, ok := currentByName[d.FieldName]
+		if !ok {
+			diff.Changes = append(diff.Changes, MappingChange{
+				Path: path,
+				Kind: MappingChangeAdded,
+				New:  describeMappingProperty(d),
+			})
+			continue
+		}
+
+		for _, attr := range mappingAttributes {
+			oldVal, newVal := attr.get(c), attr.get(d)
+			if oldVal == newVal {
+				continue
+			}
+			diff.Changes = append(diff.Changes, MappingChange{
+				Path:      path,
+				Kind:      MappingChangeChanged,
+				Attribute: attr.name,
+				Old:       oldVal,
+				New:       newVal,
+				Breaking:  !attr.updatable,
+			})
+		}
+		diffProperties(diff, path+".", c.Fields, d.Fields, false)
+		diffProperties(diff, path+".", c.Children, d.Children, isDynamicObject(d, dynamic))
+	}
+}
+
+// isDynamicRoot tells whether OpenSearch adds unknown fields to the root of a mapping, given its "dynamic" setting
+// if set is true. A mapping without the setting is dynamic, but without set the root is taken as strict.
+func isDynamicRoot(set bool, dynamic *string) bool {
+	return set && (dynamic == nil || *dynamic != "strict")
+}
+
+// isDynamicObject tells whether OpenSearch adds unknown fields to an object property, given whether it does so to the
+// parent object.
+func isDynamicObject(p MappingProperty, parentDynamic bool) bool {
+	switch {
+	case p.DynamicTemplate != nil:
+		return true
+	case p.Dynamic != nil:
+		return *p.Dynamic != "strict"
+	default:
+		return parentDynamic
+	}
+}
+
+// IsCompatible tells whether the desired mapping can be applied to the current one with the put-mapping API.
+func (d *MappingDiff) IsCompatible() bool {
+	return len(d.BreakingChanges()) == 0
+}
+
+// IsEmpty tells whether the mappings are equal.
+func (d *MappingDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+func (d *MappingDiff) BreakingChanges() []MappingChange {
+	var changes []MappingChange
+	for _, c := range d.Changes {
+		if c.Breaking {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+func (d *MappingDiff) CompatibleChanges() []MappingChange {
+	var changes []MappingChange
+	for _, c := range d.Changes {
+		if !c.Breaking {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// String returns a human-readable report with a line per change.
+func (d *MappingDiff) String() string {
+	if d.IsEmpty() {
+		return "no changes"
+	}
+	var sb strings.Builder
+	for _, c := range d.Changes {
+		sb.WriteString(c.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// MarshalJSON adds a "compatible" field to the JSON report.
+func (d *MappingDiff) MarshalJSON() ([]byte, error) {
+	changes := d.Changes
+	if changes == nil {
+		changes = []MappingChange{}
+	}
+	return json.Marshal(struct {
+		Compatible bool            `json:"compatible"`
+		Changes    []MappingChange `json:"changes"`
+	}{
+		Compatible: d.IsCompatible(),
+		Changes:    changes,
+	})
+}
+
+func (c MappingChange) String() string {
+	compatibility := "compatible"
+	if c.Breaking {
+		compatibility = "breaking"
+	}
+	switch c.Kind {
+	case MappingChangeAdded:
+		return fmt.Sprintf("+ %s: added %s (%s)", c.Path, c.New, compatibility)
+	case MappingChangeRemoved:
+		return fmt.Sprintf("- %s: removed %s (%s)", c.Path, c.Old, compatibility)
+	default:
+		return fmt.Sprintf("~ %s: %s changed from %q to %q (%s)", c.Path, c.Attribute, c.Old, c.New, compatibility)
+	}
+}
+
+// normalizedFieldType returns the type of a property, with "object" for objects that have no explicit type.
+func normalizedFieldType(p MappingProperty) string {
+	if p.FieldType == "" && p.Children != nil {
+		return fieldTypeObject
+	}
+	return p.FieldType
+}
+
+func describeMappingProperty(p MappingProperty) string {
+	desc := normalizedFieldType(p)
+	if p.FieldFormat != nil {
+		desc += " (" + *p.FieldFormat + ")"
+	}
+	return desc
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func boolOrEmpty(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return fmt.Sprint(*b)
+}
+
+func formatStringMap(m map[string]string) string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ";")
+}
@@ -0,0 +1,28 @@
+package opensearchutil
+
+type MappingDiffOption interface {
+	apply(*mappingDiffOptionContainer)
+}
+
+type mappingDiffOptionContainer struct {
+	rootDynamicSet bool
+	rootDynamic    *string
+}
+
+type rootDynamicOption struct {
+	dynamic *string
+}
+
+func (c rootDynamicOption) apply(opts *mappingDiffOptionContainer) {
+	opts.rootDynamicSet = true
+	opts.rootDynamic = c.dynamic
+}
+
+// WithRootDynamic sets the "dynamic" setting of the root mapping, e.g. ParsedIndex.Dynamic of the current index. nil
+// means the mapping has none, which OpenSearch takes as "true". Unless it is "strict", DiffMappingProperties does not
+// report fields that only the current mapping has at the root. Without this option, the root is taken as strict.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithRootDynamic(dynamic *string) MappingDiffOption {
+	return rootDynamicOption{dynamic: dynamic}
+}
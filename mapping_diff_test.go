@@ -0,0 +1,163 @@
+package opensearchutil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestDiffMappingProperties_ReportsNoChangesForEqualMappings(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mps := []MappingProperty{
+		{FieldName: "name", FieldType: "text"},
+		{FieldName: "company", Children: []MappingProperty{{FieldName: "name", FieldType: "keyword"}}},
+	}
+	parsed := []MappingProperty{
+		{FieldName: "company", FieldType: "object", Children: []MappingProperty{{FieldName: "name", FieldType: "keyword"}}},
+		{FieldName: "name", FieldType: "text"},
+	}
+
+	diff := DiffMappingProperties(mps, parsed)
+	g.Expect(diff.IsEmpty()).To(gomega.BeTrue())
+	g.Expect(diff.IsCompatible()).To(gomega.BeTrue())
+	g.Expect(diff.String()).To(gomega.Equal("no changes"))
+}
+
+func TestDiffMappingProperties_ClassifiesChanges(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	current := []MappingProperty{
+		{FieldName: "age", FieldType: "integer"},
+		{FieldName: "removed", FieldType: "keyword"},
+		{
+			FieldName: "title",
+			FieldType: "text",
+			Analyzer:  MakePtr("standard"),
+			Fields:    []MappingProperty{{FieldName: "raw", FieldType: "keyword", IgnoreAbove: MakePtr(uint32(256))}},
+		},
+		{FieldName: "addresses", Children: []MappingProperty{{FieldName: "city", FieldType: "keyword"}}},
+		{FieldName: "created_at", FieldType: "date", FieldFormat: MakePtr("basic_date")},
+	}
+	desired := []MappingProperty{
+		{FieldName: "age", FieldType: "long"},
+		{
+			FieldName:      "title",
+			FieldType:      "text",
+			Analyzer:       MakePtr("english"),
+			SearchAnalyzer: MakePtr("english"),
+			Fields: []MappingProperty{
+				{FieldName: "raw", FieldType: "keyword", IgnoreAbove: MakePtr(uint32(512))},
+				{FieldName: "english", FieldType: "text"},
+			},
+		},
+		{
+			FieldName: "addresses",
+			FieldType: "nested",
+			Children: []MappingProperty{
+				{FieldName: "city", FieldType: "keyword"},
+				{FieldName: "zip", FieldType: "keyword"},
+			},
+		},
+		{FieldName: "created_at", FieldType: "date", FieldFormat: MakePtr("basic_date_time")},
+		{FieldName: "email", FieldType: "keyword"},
+	}
+
+	diff := DiffMappingProperties(current, desired)
+	g.Expect(diff.Changes).To(gomega.Equal([]MappingChange{
+		{Path: "addresses", Kind: MappingChangeChanged, Attribute: "type", Old: "object", New: "nested", Breaking: true},
+		{Path: "addresses.zip", Kind: MappingChangeAdded, New: "keyword"},
+		{Path: "age", Kind: MappingChangeChanged, Attribute: "type", Old: "integer", New: "long", Breaking: true},
+		{
+			Path:      "created_at",
+			Kind:      MappingChangeChanged,
+			Attribute: "format",
+			Old:       "basic_date",
+			New:       "basic_date_time",
+			Breaking:  true,
+		},
+		{Path: "email", Kind: MappingChangeAdded, New: "keyword"},
+		{Path: "removed", Kind: MappingChangeRemoved, Old: "keyword", Breaking: true},
+		{Path: "title", Kind: MappingChangeChanged, Attribute: "analyzer", Old: "standard", New: "english", Breaking: true},
+		{Path: "title", Kind: MappingChangeChanged, Attribute: "search_analyzer", New: "english"},
+		{Path: "title.english", Kind: MappingChangeAdded, New: "text"},
+		{Path: "title.raw", Kind: MappingChangeChanged, Attribute: "ignore_above", Old: "256", New: "512"},
+	}))
+	g.Expect(diff.IsCompatible()).To(gomega.BeFalse())
+	g.Expect(diff.BreakingChanges()).To(gomega.HaveLen(5))
+	g.Expect(diff.CompatibleChanges()).To(gomega.HaveLen(5))
+	g.Expect(diff.String()).To(gomega.ContainSubstring(`~ age: type changed from "integer" to "long" (breaking)`))
+	g.Expect(diff.String()).To(gomega.ContainSubstring("+ email: added keyword (compatible)\n"))
+	g.Expect(diff.String()).To(gomega.ContainSubstring("- removed: removed keyword (breaking)\n"))
+}
+
+func TestDiffMappingProperties_OnlyAdditionsAreCompatible(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	current := []MappingProperty{{FieldName: "name", FieldType: "text"}}
+	desired := []MappingProperty{
+		{FieldName: "name", FieldType: "text", Fields: []MappingProperty{{FieldName: "raw", FieldType: "keyword"}}},
+		{FieldName: "age", FieldType: "integer"},
+	}
+
+	diff := DiffMappingProperties(current, desired)
+	g.Expect(diff.IsCompatible()).To(gomega.BeTrue())
+
+	jsonBytes, err := json.Marshal(diff)
+	g.Expect(err).To(gomega.BeNil())
+	assertJsonsEqual(g, jsonBytes, []byte(`{
+		"compatible": true,
+		"changes": [
+			{"path": "age", "kind": "added", "new": "integer", "breaking": false},
+			{"path": "name.raw", "kind": "added", "new": "keyword", "breaking": false}
+		]
+	}`))
+}
+
+func TestDiffMappingProperties_IgnoresDynamicallyAddedFields(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		Title  string            `json:"title"`
+		Labels map[string]string `json:"labels" opensearch:"map:object"`
+		Counts map[string]int    `json:"counts" opensearch:"map:dynamic_template"`
+		Meta   struct {
+			Source string `json:"source"`
+		} `json:"meta"`
+	}
+	desired, err := NewMappingPropertiesBuilder(WithJsonTagFieldNames()).BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+
+	// The live mapping after documents with new fields were indexed into an index created without "dynamic": "strict"
+	index, err := NewMappingParser().ParseIndexJson([]byte(`{
+		"mappings": {
+			"dynamic_templates": [
+				{"counts": {"path_match": "counts.*", "mapping": {"type": "integer"}}}
+			],
+			"properties": {
+				"title": {"type": "text"},
+				"author": {"type": "text", "fields": {"keyword": {"type": "keyword", "ignore_above": 256}}},
+				"labels": {"type": "object", "dynamic": "true", "properties": {"env": {"type": "text"}}},
+				"counts": {"type": "object", "dynamic": "true", "properties": {"views": {"type": "integer"}}},
+				"meta": {"properties": {"source": {"type": "text"}, "origin": {"type": "text"}}}
+			}
+		}
+	}`))
+	g.Expect(err).To(gomega.BeNil())
+
+	diff := DiffMappingProperties(index.MappingProperties, desired, WithRootDynamic(index.Dynamic))
+	g.Expect(diff.IsEmpty()).To(gomega.BeTrue(), diff.String())
+
+	// Under a strict root, fields that the desired mapping does not have were removed from it
+	diff = DiffMappingProperties(index.MappingProperties, desired, WithRootDynamic(MakePtr("strict")))
+	g.Expect(diff.String()).To(gomega.Equal(
+		"- author: removed text (breaking)\n" +
+			"- meta.origin: removed text (breaking)\n"))
+
+	// Objects with an explicit "dynamic": "strict" do not inherit the dynamic root
+	desired[3].Dynamic = MakePtr("strict")
+	diff = DiffMappingProperties(index.MappingProperties, desired, WithRootDynamic(nil))
+	g.Expect(diff.BreakingChanges()).To(gomega.HaveLen(1))
+	g.Expect(diff.BreakingChanges()[0].Path).To(gomega.Equal("meta.origin"))
+}
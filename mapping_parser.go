@@ -0,0 +1,360 @@
+package opensearchutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MappingParser parses mapping JSON documents back into mapping properties, the reverse of what IndexGenerator does.
+type MappingParser struct{}
+
+// ParsedIndex is an index definition read by MappingParser.
+type ParsedIndex struct {
+	MappingProperties []MappingProperty
+
+	// Dynamic is the "dynamic" setting of the root mapping, e.g. "strict"
+	Dynamic *string
+
+	// Settings is nil if the document has no settings. Settings that IndexSettings has no field for, and analysis
+	// settings, are left out.
+	Settings *IndexSettings
+}
+
+type (
+	rawIndex struct {
+		Mappings *rawObject                 `json:"mappings"`
+		Settings map[string]json.RawMessage `json:"settings"`
+	}
+	rawObject struct {
+		Dynamic          *jsonScalar                     `json:"dynamic"`
+		DynamicTemplates []map[string]rawDynamicTemplate `json:"dynamic_templates"`
+		Properties       map[string]rawProperty          `json:"properties"`
+	}
+	rawDynamicTemplate struct {
+		PathMatch string      `json:"path_match"`
+		Mapping   rawProperty `json:"mapping"`
+	}
+	rawProperty struct {
+		Type            string                 `json:"type"`
+		Format          *string                `json:"format"`
+		Analyzer        *string                `json:"analyzer"`
+		SearchAnalyzer  *string                `json:"search_analyzer"`
+		Normalizer      *string                `json:"normalizer"`
+		IgnoreAbove     *uint32                `json:"ignore_above"`
+		ScalingFactor   *float64               `json:"scaling_factor"`
+		CopyTo          stringList             `json:"copy_to"`
+		IndexPrefixes   map[string]jsonScalar  `json:"index_prefixes"`
+		IncludeInParent *bool                  `json:"include_in_parent"`
+		IncludeInRoot   *bool                  `json:"include_in_root"`
+		Dynamic         *jsonScalar            `json:"dynamic"`
+		Fields          map[string]rawProperty `json:"fields"`
+		Properties      map[string]rawProperty `json:"properties"`
+	}
+
+	// jsonScalar is a JSON string, number or boolean read as a string, e.g. "dynamic" can be given as true or "true".
+	jsonScalar string
+
+	// stringList is a JSON string or an array of strings, e.g. "copy_to" can be given either way.
+	stringList []string
+)
+
+func NewMappingParser() *MappingParser {
+	return &MappingParser{}
+}
+
+// ParseIndexJson parses a single index definition. The document can be a create-index body (with "mappings" and
+// "settings"), a response of the get-mapping or get-index API for one index (where the definition is wrapped in an
+// object keyed by the index name), or a bare mappings document with "properties".
+func (p *MappingParser) ParseIndexJson(data []byte) (*ParsedIndex, error) {
+	indices, err := p.ParseIndicesJson(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "ParseIndicesJson")
+	}
+	if len(indices) != 1 {
+		return nil, fmt.Errorf("expected a definition of one index, got %d", len(indices))
+	}
+	for _, index := range indices {
+		return index, nil
+	}
+	return nil, nil
+}
+
+// ParseIndicesJson parses a response of the get-mapping or get-index API, which can contain multiple indices, into a
+// map from the index name to its definition. Documents that are not wrapped in index names (see ParseIndexJson) are
+// returned under the key "".
+func (p *MappingParser) ParseIndicesJson(data []byte) (map[string]*ParsedIndex, error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return nil, errors.Wrapf(err, "json.Unmarshal")
+	}
+
+	if _, ok := top["properties"]; ok {
+		index, err := p.parseRawIndex(data, true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parseRawIndex")
+		}
+		return map[string]*ParsedIndex{"": index}, nil
+	}
+	if isIndexDefinition(top) {
+		index, err := p.parseRawIndex(data, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parseRawIndex")
+		}
+		return map[string]*ParsedIndex{"": index}, nil
+	}
+
+	indices := make(map[string]*ParsedIndex, len(top))
+	for name, body := range top {
+		var inner map[string]json.RawMessage
+		if err := json.Unmarshal(body, &inner); err != nil || !isIndexDefinition(inner) {
+			return nil, fmt.Errorf("unrecognized mapping document: %q is not an index definition", name)
+		}
+		index, err := p.parseRawIndex(body, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parseRawIndex %s", name)
+		}
+		indices[name] = index
+	}
+	return indices, nil
+}
+
+func isIndexDefinition(obj map[string]json.RawMessage) bool {
+	_, hasMappings := obj["mappings"]
+	_, hasSettings := obj["settings"]
+	return hasMappings || hasSettings
+}
+
+func (p *MappingParser) parseRawIndex(data []byte, bare bool) (*ParsedIndex, error) {
+	var raw rawIndex
+	if bare {
+		raw.Mappings = &rawObject{}
+		if err := json.Unmarshal(data, raw.Mappings); err != nil {
+			return nil, errors.Wrapf(err, "json.Unmarshal")
+		}
+	} else if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrapf(err, "json.Unmarshal")
+	}
+
+	index := &ParsedIndex{}
+	if raw.Mappings != nil {
+		index.MappingProperties = p.parseProperties(raw.Mappings.Properties)
+		if raw.Mappings.Dynamic != nil {
+			index.Dynamic = MakePtr(string(*raw.Mappings.Dynamic))
+		}
+		if err := p.attachDynamicTemplates(index.MappingProperties, raw.Mappings.DynamicTemplates); err != nil {
+			return nil, errors.Wrapf(err, "attachDynamicTemplates")
+		}
+	}
+	if raw.Settings != nil {
+		settings, err := parseIndexSettings(raw.Settings)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parseIndexSettings")
+		}
+		index.Settings = settings
+	}
+	return index, nil
+}
+
+// parseProperties converts raw properties into mapping properties, sorted by field name.
+func (p *MappingParser) parseProperties(properties map[string]rawProperty) []MappingProperty {
+	if len(properties) == 0 {
+		return nil
+	}
+	mps := make([]MappingProperty, 0, len(properties))
+	for name, raw := range properties {
+		mps = append(mps, p.parseProperty(name, raw))
+	}
+	sort.Slice(mps, func(i, j int) bool {
+		return mps[i].FieldName < mps[j].FieldName
+	})
+	return mps
+}
+
+func (p *MappingParser) parseProperty(name string, raw rawProperty) MappingProperty {
+	mp := MappingProperty{
+		FieldName:       name,
+		FieldType:       raw.Type,
+		FieldFormat:     raw.Format,
+		Analyzer:        raw.Analyzer,
+		SearchAnalyzer:  raw.SearchAnalyzer,
+		Normalizer:      raw.Normalizer,
+		IgnoreAbove:     raw.IgnoreAbove,
+		ScalingFactor:   raw.ScalingFactor,
+		CopyTo:          raw.CopyTo,
+		IncludeInParent: raw.IncludeInParent,
+		IncludeInRoot:   raw.IncludeInRoot,
+		Fields:          p.parseProperties(raw.Fields),
+		Children:        p.parseProperties(raw.Properties),
+	}
+	if raw.IndexPrefixes != nil {
+		indexPrefixes := make(map[string]string, len(raw.IndexPrefixes))
+		for k, v := range raw.IndexPrefixes {
+			indexPrefixes[k] = string(v)
+		}
+		mp.IndexPrefixes = &indexPrefixes
+	}
+	if raw.Dynamic != nil {
+		mp.Dynamic = MakePtr(string(*raw.Dynamic))
+	}
+	return mp
+}
+
+// attachDynamicTemplates sets MappingProperty.DynamicTemplate for the templates that match all fields under a
+// property, i.e. those with a "path_match" of "path.to.property.*". Other templates are not represented in mapping
+// properties and are left out.
+func (p *MappingParser) attachDynamicTemplates(
+	mappingProperties []MappingProperty,
+	templates []map[string]rawDynamicTemplate,
+) error {
+	for _, template := range templates {
+		for name, t := range template {
+			path := strings.TrimSuffix(t.PathMatch, ".*")
+			if path == t.PathMatch {
+				continue
+			}
+			mp := findMappingProperty(mappingProperties, strings.Split(path, "."))
+			if mp == nil {
+				continue
+			}
+			if len(t.Mapping.Properties) > 0 {
+				return fmt.Errorf("dynamic template %s: object mappings are not supported", name)
+			}
+			valueProperty := p.parseProperty(mp.FieldName, t.Mapping)
+			mp.DynamicTemplate = &valueProperty
+		}
+	}
+	return nil
+}
+
+// findMappingProperty finds a property by its path, e.g. []string{"company", "name"}.
+func findMappingProperty(mappingProperties []MappingProperty, path []string) *MappingProperty {
+	for i := range mappingProperties {
+		if mappingProperties[i].FieldName != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			return &mappingProperties[i]
+		}
+		return findMappingProperty(mappingProperties[i].Children, path[1:])
+	}
+	return nil
+}
+
+// parseIndexSettings reads index settings given either nested ({"index": {"number_of_shards": 1}}) or with dotted
+// keys ({"index.number_of_shards": "1"}), with values as JSON types or as strings, as the get-settings API returns
+// them.
+func parseIndexSettings(raw map[string]json.RawMessage) (*IndexSettings, error) {
+	flat := map[string]string{}
+	if err := flattenSettings(raw, "", flat); err != nil {
+		return nil, errors.Wrapf(err, "flattenSettings")
+	}
+
+	settings := &IndexSettings{}
+	v := reflect.ValueOf(settings).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		val, ok := flat[name]
+		if !ok {
+			continue
+		}
+		if err := setSettingValue(v.Field(i), val); err != nil {
+			return nil, errors.Wrapf(err, "setSettingValue %s", name)
+		}
+	}
+	return settings, nil
+}
+
+func flattenSettings(raw map[string]json.RawMessage, prefix string, flat map[string]string) error {
+	for key, val := range raw {
+		key = strings.TrimPrefix(prefix+key, "index.")
+		if key == "index" {
+			key = ""
+		}
+		if bytes.HasPrefix(bytes.TrimSpace(val), []byte("{")) {
+			var inner map[string]json.RawMessage
+			if err := json.Unmarshal(val, &inner); err != nil {
+				return errors.Wrapf(err, "json.Unmarshal %s", key)
+			}
+			innerPrefix := ""
+			if key != "" {
+				innerPrefix = key + "."
+			}
+			if err := flattenSettings(inner, innerPrefix, flat); err != nil {
+				return err
+			}
+			continue
+		}
+		var scalar jsonScalar
+		if err := json.Unmarshal(val, &scalar); err != nil {
+			continue // Arrays are not supported by IndexSettings
+		}
+		flat[key] = string(scalar)
+	}
+	return nil
+}
+
+// setSettingValue sets a pointer field of IndexSettings from a string value.
+func setSettingValue(field reflect.Value, val string) error {
+	elemType := field.Type().Elem()
+	ptr := reflect.New(elemType)
+	switch elemType.Kind() {
+	case reflect.String:
+		ptr.Elem().SetString(val)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return errors.Wrapf(err, "strconv.ParseBool")
+		}
+		ptr.Elem().SetBool(b)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(val, 10, elemType.Bits())
+		if err != nil {
+			return errors.Wrapf(err, "strconv.ParseUint")
+		}
+		ptr.Elem().SetUint(u)
+	default:
+		return fmt.Errorf("unsupported setting type %s", elemType)
+	}
+	field.Set(ptr)
+	return nil
+}
+
+func (s *jsonScalar) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*s = jsonScalar(str)
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return errors.Wrapf(err, "json.Unmarshal")
+	}
+	switch v.(type) {
+	case bool, float64:
+		*s = jsonScalar(bytes.TrimSpace(data))
+		return nil
+	default:
+		return fmt.Errorf("expected a string, number or boolean, got %s", data)
+	}
+}
+
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*l = stringList{str}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return errors.Wrapf(err, "json.Unmarshal")
+	}
+	*l = list
+	return nil
+}
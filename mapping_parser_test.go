@@ -0,0 +1,138 @@
+package opensearchutil
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestMappingParser_ParseIndexJson_ParsesGeneratedIndex(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mappingProperties := []MappingProperty{
+		{
+			FieldName: "addresses",
+			FieldType: "nested",
+			Children: []MappingProperty{
+				{FieldName: "city", FieldType: "keyword"},
+			},
+			IncludeInParent: MakePtr(true),
+		},
+		{
+			FieldName:   "created_at",
+			FieldType:   "date",
+			FieldFormat: MakePtr("basic_date_time"),
+		},
+		{
+			FieldName:       "labels",
+			FieldType:       "object",
+			Dynamic:         MakePtr("true"),
+			DynamicTemplate: &MappingProperty{FieldName: "labels", FieldType: "keyword"},
+		},
+		{
+			FieldName:     "name",
+			FieldType:     "text",
+			IndexPrefixes: MakePtr(map[string]string{"min_chars": "2"}),
+			Fields: []MappingProperty{
+				{FieldName: "raw", FieldType: "keyword", IgnoreAbove: MakePtr(uint32(256))},
+			},
+		},
+		{
+			FieldName:      "title",
+			FieldType:      "text",
+			Analyzer:       MakePtr("standard"),
+			SearchAnalyzer: MakePtr("english"),
+			CopyTo:         []string{"all_text"},
+		},
+	}
+	settings := &IndexSettings{
+		NumberOfShards:  MakePtr(uint16(2)),
+		Hidden:          MakePtr(true),
+		RefreshInterval: MakePtr("1s"),
+	}
+
+	indexJson, err := NewIndexGenerator().GenerateIndexJson(mappingProperties, settings, WithStrictMapping(true))
+	g.Expect(err).To(gomega.BeNil())
+
+	index, err := NewMappingParser().ParseIndexJson(indexJson)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(index.MappingProperties).To(gomega.Equal(mappingProperties))
+	g.Expect(index.Dynamic).To(gomega.Equal(MakePtr("strict")))
+	g.Expect(index.Settings).To(gomega.Equal(settings))
+}
+
+func TestMappingParser_ParseIndexJson_ParsesBarePropertiesDocument(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	index, err := NewMappingParser().ParseIndexJson([]byte(`{
+		"properties": {
+			"title": {"type": "text", "copy_to": "all_text"},
+			"company": {"properties": {"name": {"type": "keyword"}}}
+		}
+	}`))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(index.Settings).To(gomega.BeNil())
+	g.Expect(index.MappingProperties).To(gomega.Equal([]MappingProperty{
+		{
+			FieldName: "company",
+			Children:  []MappingProperty{{FieldName: "name", FieldType: "keyword"}},
+		},
+		{FieldName: "title", FieldType: "text", CopyTo: []string{"all_text"}},
+	}))
+}
+
+func TestMappingParser_ParseIndicesJson_ParsesApiResponses(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	indices, err := NewMappingParser().ParseIndicesJson([]byte(`{
+		"people-v1": {
+			"aliases": {},
+			"mappings": {
+				"dynamic": false,
+				"properties": {"name": {"type": "text"}}
+			},
+			"settings": {
+				"index": {
+					"number_of_shards": "1",
+					"number_of_replicas": "2",
+					"hidden": "true",
+					"soft_deletes": {"retention_lease": {"period": "12h"}},
+					"uuid": "abc",
+					"provided_name": "people-v1"
+				}
+			}
+		},
+		"people-v2": {
+			"mappings": {"properties": {"age": {"type": "integer"}}}
+		}
+	}`))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(indices).To(gomega.HaveLen(2))
+
+	g.Expect(indices["people-v1"].Dynamic).To(gomega.Equal(MakePtr("false")))
+	g.Expect(indices["people-v1"].MappingProperties).To(gomega.Equal([]MappingProperty{
+		{FieldName: "name", FieldType: "text"},
+	}))
+	g.Expect(indices["people-v1"].Settings).To(gomega.Equal(&IndexSettings{
+		NumberOfShards:                  MakePtr(uint16(1)),
+		NumberOfReplicas:                MakePtr(uint16(2)),
+		Hidden:                          MakePtr(true),
+		SoftDeletesRetentionLeasePeriod: MakePtr("12h"),
+	}))
+	g.Expect(indices["people-v2"].MappingProperties).To(gomega.Equal([]MappingProperty{
+		{FieldName: "age", FieldType: "integer"},
+	}))
+
+	_, err = NewMappingParser().ParseIndexJson([]byte(`{"a": {"mappings": {}}, "b": {"mappings": {}}}`))
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestMappingParser_ParseIndexJson_ErrorsOnUnrecognizedDocument(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	_, err := NewMappingParser().ParseIndexJson([]byte(`{"foo": "bar"}`))
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	_, err = NewMappingParser().ParseIndexJson([]byte(`{"settings": {"number_of_shards": "many"}}`))
+	g.Expect(err).To(gomega.HaveOccurred())
+}
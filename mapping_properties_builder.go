@@ -3,6 +3,8 @@ package opensearchutil
 import (
 	"fmt"
 	"reflect"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,13 +12,19 @@ import (
 
 type MappingPropertiesBuilder struct {
 	optionContainer mappingPropertiesBuilderOptionContainer
+
+	// cache maps from a reflect.Type of a struct to its []MappingProperty. Options of a builder do not change, so
+	// the cache is valid for the builder's options.
+	cache sync.Map
 }
 
 type fieldWrapper struct {
 	field       reflect.StructField
 	kind        reflect.Kind
 	value       reflect.Value
+	tags        fieldTags
 	isPrimitive bool
+	isSlice     bool
 }
 
 func NewMappingPropertiesBuilder(options ...MappingPropertiesBuilderOption) *MappingPropertiesBuilder {
@@ -29,6 +37,9 @@ func NewMappingPropertiesBuilder(options ...MappingPropertiesBuilderOption) *Map
 	if optContainer.fieldNameTransformer == nil {
 		optContainer.fieldNameTransformer = NewSnakeCaser()
 	}
+	if optContainer.useJsonTags {
+		optContainer.fieldNameTransformer = NewJsonTagFieldNameTransformer(optContainer.fieldNameTransformer)
+	}
 	if optContainer.jsonFormatter == nil {
 		optContainer.jsonFormatter = NewMarshalIndentJsonFormatter()
 	}
@@ -36,83 +47,233 @@ func NewMappingPropertiesBuilder(options ...MappingPropertiesBuilderOption) *Map
 	return &MappingPropertiesBuilder{optionContainer: optContainer}
 }
 
+// BuildMappingProperties builds mapping properties of a struct. obj can be a struct, a pointer to a struct (which can
+// be nil) or the reflect.Type of either. Only the type of obj is used, never its field values.
+// Problems with fields are reported as *BuildError, or as BuildErrors with the CollectAllErrors option.
+// Results are cached per type, so reusing a builder makes repeated calls for the same type cheap. It is safe to call
+// concurrently, and the returned properties are copies that can be modified freely.
 func (b *MappingPropertiesBuilder) BuildMappingProperties(obj interface{}) ([]MappingProperty, error) {
-	mps, err := b.doBuildMappingProperties(obj, 1)
+	t, err := resolveInputType(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolveInputType")
+	}
+	if cached, ok := b.cache.Load(t); ok {
+		return cloneMappingProperties(cached.([]MappingProperty)), nil
+	}
+
+	collector := &buildErrorCollector{collectAll: b.optionContainer.collectAllErrors}
+	mps, err := b.doBuildMappingProperties(t, 1, buildPath{goPath: typeName(t)}, collector)
 	if err != nil {
-		return nil, errors.Wrapf(err, "b.doBuildMappingProperties")
+		return nil, err
+	}
+	if len(collector.errs) > 0 {
+		return nil, collector.errs
+	}
+	b.cache.Store(t, mps)
+	return cloneMappingProperties(mps), nil
+}
+
+func typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.String()
+}
+
+// defaultMappingPropertiesBuilder is the builder of BuildMappingPropertiesFor without options, shared so that its
+// results are cached across calls.
+var defaultMappingPropertiesBuilder = NewMappingPropertiesBuilder()
+
+// BuildMappingPropertiesFor builds mapping properties of struct type T (or of the struct T points to). Without options,
+// it uses a package-level builder whose results are cached per type. With options, it creates a new builder on every
+// call, so nothing is cached: to build with options repeatedly, reuse a MappingPropertiesBuilder instead.
+func BuildMappingPropertiesFor[T any](options ...MappingPropertiesBuilderOption) ([]MappingProperty, error) {
+	builder := defaultMappingPropertiesBuilder
+	if len(options) > 0 {
+		builder = NewMappingPropertiesBuilder(options...)
+	}
+	mps, err := builder.BuildMappingProperties(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, errors.Wrapf(err, "BuildMappingProperties")
 	}
 	return mps, nil
 }
 
+// resolveInputType returns the struct type of the object given to BuildMappingProperties.
+func resolveInputType(obj interface{}) (reflect.Type, error) {
+	t, ok := obj.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(obj)
+	}
+	if t == nil {
+		return nil, &InvalidInputError{}
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, &InvalidInputError{Type: t}
+	}
+	return t, nil
+}
+
+// buildPath is the location of a struct being mapped, used to report errors.
+type buildPath struct {
+	goPath string // e.g. "Order.Lines[]"
+	osPath string // e.g. "lines", empty for the root
+}
+
+func (p buildPath) child(field reflect.StructField, propertyName string, isSlice bool) buildPath {
+	goPath := p.goPath + "." + field.Name
+	if isSlice {
+		goPath += "[]"
+	}
+	osPath := propertyName
+	if p.osPath != "" {
+		osPath = p.osPath + "." + propertyName
+	}
+	return buildPath{goPath: goPath, osPath: osPath}
+}
+
+func (p buildPath) error(err error) *BuildError {
+	return &BuildError{GoPath: p.goPath, Path: p.osPath, Err: err}
+}
+
+// buildErrorCollector collects errors of all fields when the CollectAllErrors option is set.
+type buildErrorCollector struct {
+	collectAll bool
+	errs       BuildErrors
+}
+
+// fail records an error. It returns the error if building should stop, or nil if it should go on with other fields.
+func (c *buildErrorCollector) fail(err *BuildError) error {
+	if !c.collectAll {
+		return err
+	}
+	c.errs = append(c.errs, err)
+	return nil
+}
+
 func (b *MappingPropertiesBuilder) doBuildMappingProperties(
-	obj interface{},
+	t reflect.Type,
 	nthLevel uint8,
+	path buildPath,
+	collector *buildErrorCollector,
 ) ([]MappingProperty, error) {
 	var mappingProperties []MappingProperty
-	v := reflect.ValueOf(obj)
-	t := v.Type()
-	for i := 0; i < t.NumField(); i++ {
-		tField := t.Field(i)
-		fieldName := tField.Name
-		resolvedField := b.resolveField(tField, v.Field(i))
-		resolvedField = b.unslice(resolvedField)
-
-		if err := validateField(resolvedField); err != nil {
-			return nil, errors.Wrapf(err, "validateField")
-		}
+	fields, err := b.collectFields(t)
+	if err != nil {
+		return nil, collector.fail(path.error(err))
+	}
+	for _, f := range fields {
+		resolvedField := b.unslice(b.resolveField(f.field))
+		fieldPath := path.child(f.field, f.name, resolvedField.isSlice)
 
-		fieldType, err := b.resolveFieldType(resolvedField)
+		mappingProperty, err := b.buildFieldProperty(resolvedField, f.name, nthLevel, fieldPath, collector)
 		if err != nil {
-			return nil, errors.Wrapf(err, "resolveFieldType")
+			var buildErr *BuildError
+			if !errors.As(err, &buildErr) {
+				buildErr = fieldPath.error(err)
+			}
+			if err := collector.fail(buildErr); err != nil {
+				return nil, err
+			}
+			continue
 		}
+		if mappingProperty != nil {
+			mappingProperties = append(mappingProperties, *mappingProperty)
+		}
+	}
+	return mappingProperties, nil
+}
 
-		fieldFormat, err := b.resolveFieldFormat(resolvedField)
+// buildFieldProperty builds the mapping property of a single field. It returns nil if the field is left out.
+func (b *MappingPropertiesBuilder) buildFieldProperty(
+	resolvedField *fieldWrapper,
+	fieldName string,
+	nthLevel uint8,
+	path buildPath,
+	collector *buildErrorCollector,
+) (*MappingProperty, error) {
+	if err := validateField(resolvedField); err != nil {
+		return nil, err
+	}
+
+	if strategy := b.resolveMapStrategy(resolvedField); strategy != "" {
+		mappingProperty, err := b.buildMapProperty(resolvedField, fieldName, strategy)
 		if err != nil {
-			return nil, errors.Wrapf(err, "resolveFieldFormat")
+			return nil, err
 		}
+		return &mappingProperty, nil
+	}
+
+	fieldType, err := b.resolveFieldType(resolvedField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolveFieldType")
+	}
+
+	fieldFormat, err := b.resolveFieldFormat(resolvedField)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolveFieldFormat")
+	}
 
-		transformedFieldName, err := b.optionContainer.fieldNameTransformer.TransformFieldName(fieldName)
+	isObject := resolvedField.kind == reflect.Struct && (fieldType == "" || isObjectFieldType(fieldType))
+	if !isObject && fieldType != "" {
+		mappingProperty := MappingProperty{
+			FieldName:   fieldName,
+			FieldType:   fieldType,
+			FieldFormat: fieldFormat,
+		}
+		if err := b.addProperties(resolvedField, &mappingProperty); err != nil {
+			return nil, err
+		}
+		return &mappingProperty, nil
+	} else if isObject {
+		if nthLevel+1 > b.optionContainer.maxDepth {
+			return nil, nil
+		}
+		children, err := b.doBuildMappingProperties(resolvedField.value.Type(), nthLevel+1, path, collector)
 		if err != nil {
-			return nil, errors.Wrapf(err, "TransformFieldName")
+			return nil, err
+		}
+		mappingProperty := MappingProperty{
+			FieldName:   fieldName,
+			FieldType:   fieldType,
+			Children:    children,
+			FieldFormat: fieldFormat,
+		}
+		if err := b.addObjectProperties(resolvedField, &mappingProperty); err != nil {
+			return nil, err
 		}
+		return &mappingProperty, nil
+	} else if !b.optionContainer.omitUnsupportedTypes {
+		return nil, fmt.Errorf(
+			"field not supported: %s, please use opensearchutil.OmitUnsupportedTypes to skip"+
+				" fields of unsupported types",
+			resolvedField.field.Name)
+	}
+	return nil, nil
+}
 
-		if fieldType != "" {
-			mappingProperty := MappingProperty{
-				FieldName:   transformedFieldName,
-				FieldType:   fieldType,
-				FieldFormat: fieldFormat,
-			}
-			if err := b.addProperties(resolvedField, &mappingProperty); err != nil {
-				return nil, errors.Wrapf(err, "addProperties")
-			}
-			mappingProperties = append(mappingProperties, mappingProperty)
-			continue
-		} else if resolvedField.kind == reflect.Struct {
-			if nthLevel+1 > b.optionContainer.maxDepth {
-				continue
-			}
-			children, err := b.doBuildMappingProperties(resolvedField.value.Interface(), nthLevel+1)
-			if err != nil {
-				return nil, errors.Wrapf(err, "nested b.doBuildMappingProperties")
-			}
-			mappingProperties = append(mappingProperties, MappingProperty{
-				FieldName:   transformedFieldName,
-				Children:    children,
-				FieldFormat: fieldFormat,
-			})
-			continue
-		} else if !b.optionContainer.omitUnsupportedTypes {
-			return nil, fmt.Errorf(
-				"field not supported: %s, please use opensearchutil.OmitUnsupportedTypes to skip"+
-					" fields of unsupported types",
-				resolvedField.field.Name)
+// transformFieldName returns the property name for a struct field, and whether the field should be left out of the
+// mapping.
+func (b *MappingPropertiesBuilder) transformFieldName(field reflect.StructField) (string, bool, error) {
+	if t, ok := b.optionContainer.fieldNameTransformer.(StructFieldNameTransformer); ok {
+		name, skip, err := t.TransformStructField(field)
+		if err != nil {
+			return "", false, errors.Wrapf(err, "TransformStructField")
 		}
+		return name, skip, nil
 	}
-	return mappingProperties, nil
+	name, err := b.optionContainer.fieldNameTransformer.TransformFieldName(field.Name)
+	if err != nil {
+		return "", false, errors.Wrapf(err, "TransformFieldName")
+	}
+	return name, false, nil
 }
 
 func (b *MappingPropertiesBuilder) addProperties(resolvedField *fieldWrapper, mappingProperty *MappingProperty) error {
-	indexPrefixes := getTagOptionValue(resolvedField.field, tagKey, tagOptionIndexPrefixes)
+	indexPrefixes := resolvedField.tags.indexPrefixes
 	if indexPrefixes != "" {
 		opts := parseCustomPropertyValue(indexPrefixes)
 		mappingProperty.IndexPrefixes = MakePtr(make(map[string]string, len(opts)))
@@ -121,24 +282,219 @@ func (b *MappingPropertiesBuilder) addProperties(resolvedField *fieldWrapper, ma
 		}
 	}
 
-	analyzer := getTagOptionValue(resolvedField.field, tagKey, tagOptionAnalyzer)
+	analyzer := resolvedField.tags.analyzer
 	if analyzer != "" {
 		mappingProperty.Analyzer = MakePtr(analyzer)
 	}
 
-	searchAnalyzer := getTagOptionValue(resolvedField.field, tagKey, tagOptionSearchAnalyzer)
+	searchAnalyzer := resolvedField.tags.searchAnalyzer
 	if searchAnalyzer != "" {
 		mappingProperty.SearchAnalyzer = MakePtr(searchAnalyzer)
 	}
 
-	copyTo := getTagOptionValue(resolvedField.field, tagKey, tagOptionCopyTo)
+	copyTo := resolvedField.tags.copyTo
 	if copyTo != "" {
 		mappingProperty.CopyTo = parseListPropertyValue(copyTo)
 	}
 
+	normalizer := resolvedField.tags.normalizer
+	if normalizer != "" {
+		mappingProperty.Normalizer = MakePtr(normalizer)
+	}
+
+	ignoreAbove := resolvedField.tags.ignoreAbove
+	if ignoreAbove != "" {
+		val, err := strconv.ParseUint(ignoreAbove, 10, 32)
+		if err != nil {
+			return errors.Wrapf(err, "strconv.ParseUint %s", tagOptionIgnoreAbove)
+		}
+		mappingProperty.IgnoreAbove = MakePtr(uint32(val))
+	}
+
+	scalingFactor := resolvedField.tags.scalingFactor
+	if scalingFactor != "" {
+		val, err := strconv.ParseFloat(scalingFactor, 64)
+		if err != nil {
+			return errors.Wrapf(err, "strconv.ParseFloat %s", tagOptionScalingFactor)
+		}
+		mappingProperty.ScalingFactor = MakePtr(val)
+	}
+
+	if err := b.addFields(resolvedField, mappingProperty); err != nil {
+		return errors.Wrapf(err, "addFields")
+	}
+
+	return nil
+}
+
+// addFields adds multi-fields defined with tag options like "fields.raw:type=keyword;ignore_above=256", and the
+// "keyword" sub-field of text fields if the KeywordSubField option is set.
+func (b *MappingPropertiesBuilder) addFields(resolvedField *fieldWrapper, mappingProperty *MappingProperty) error {
+	for _, opt := range resolvedField.tags.fields {
+		if opt.key == "" {
+			return fmt.Errorf("missing multi-field name in %q: %s", tagOptionFieldsPrefix, resolvedField.field.Name)
+		}
+		subField, err := parseSubField(opt.key, opt.val)
+		if err != nil {
+			return errors.Wrapf(err, "parseSubField %s", opt.key)
+		}
+		mappingProperty.Fields = append(mappingProperty.Fields, subField)
+	}
+
+	if b.optionContainer.keywordSubField != nil && mappingProperty.FieldType == fieldTypeText {
+		for _, f := range mappingProperty.Fields {
+			if f.FieldName == fieldTypeKeyword {
+				return nil
+			}
+		}
+		mappingProperty.Fields = append(mappingProperty.Fields, MappingProperty{
+			FieldName:   fieldTypeKeyword,
+			FieldType:   fieldTypeKeyword,
+			IgnoreAbove: MakePtr(*b.optionContainer.keywordSubField),
+		})
+	}
+	return nil
+}
+
+// parseSubField parses a multi-field definition like "type=keyword;ignore_above=256". The type defaults to
+// "keyword".
+func parseSubField(name string, definition string) (MappingProperty, error) {
+	subField := MappingProperty{
+		FieldName: name,
+		FieldType: fieldTypeKeyword,
+	}
+	for key, val := range parseCustomPropertyValue(definition) {
+		switch key {
+		case tagOptionType:
+			subField.FieldType = val
+		case tagOptionAnalyzer:
+			subField.Analyzer = MakePtr(val)
+		case tagOptionSearchAnalyzer:
+			subField.SearchAnalyzer = MakePtr(val)
+		case tagOptionNormalizer:
+			subField.Normalizer = MakePtr(val)
+		case tagOptionIgnoreAbove:
+			ignoreAbove, err := strconv.ParseUint(val, 10, 32)
+			if err != nil {
+				return MappingProperty{}, errors.Wrapf(err, "strconv.ParseUint %s", tagOptionIgnoreAbove)
+			}
+			subField.IgnoreAbove = MakePtr(uint32(ignoreAbove))
+		default:
+			return MappingProperty{}, fmt.Errorf("unsupported multi-field option: %s", key)
+		}
+	}
+	return subField, nil
+}
+
+// resolveMapStrategy returns the strategy to map a map field with, or "" if the field is not a map or should not be
+// mapped with a strategy.
+func (b *MappingPropertiesBuilder) resolveMapStrategy(field *fieldWrapper) MapStrategy {
+	if field.kind != reflect.Map || field.value.Type().Key().Kind() != reflect.String {
+		return ""
+	}
+	if _, ok := getTypeProvider(field.value.Type()); ok {
+		return ""
+	}
+	if strategy := field.tags.mapStrategy; strategy != "" {
+		return MapStrategy(strategy)
+	}
+	if field.tags.fieldType != "" {
+		return "" // The type given in the tag overrides the default strategy
+	}
+	return b.optionContainer.mapStrategy
+}
+
+func (b *MappingPropertiesBuilder) buildMapProperty(
+	field *fieldWrapper,
+	fieldName string,
+	strategy MapStrategy,
+) (MappingProperty, error) {
+	switch strategy {
+	case MapStrategyFlatObject:
+		return MappingProperty{FieldName: fieldName, FieldType: string(MapStrategyFlatObject)}, nil
+	case MapStrategyObject:
+		return MappingProperty{FieldName: fieldName, FieldType: fieldTypeObject, Dynamic: MakePtr("true")}, nil
+	case MapStrategyDynamicTemplate:
+		valueField := b.unslice(b.resolveMapValue(field))
+		fieldType, err := b.resolveFieldType(valueField)
+		if err != nil {
+			return MappingProperty{}, errors.Wrapf(err, "resolveFieldType")
+		}
+		if fieldType == "" || isObjectFieldType(fieldType) {
+			return MappingProperty{}, fmt.Errorf(
+				"values of map field %s must map to a field type to be used in a dynamic template, got %s",
+				field.field.Name, valueField.value.Type())
+		}
+		fieldFormat, err := b.resolveFieldFormat(valueField)
+		if err != nil {
+			return MappingProperty{}, errors.Wrapf(err, "resolveFieldFormat")
+		}
+		valueProperty := MappingProperty{
+			FieldName:   fieldName,
+			FieldType:   fieldType,
+			FieldFormat: fieldFormat,
+		}
+		if err := b.addProperties(valueField, &valueProperty); err != nil {
+			return MappingProperty{}, errors.Wrapf(err, "addProperties")
+		}
+		return MappingProperty{
+			FieldName:       fieldName,
+			FieldType:       fieldTypeObject,
+			Dynamic:         MakePtr("true"),
+			DynamicTemplate: &valueProperty,
+		}, nil
+	default:
+		return MappingProperty{}, fmt.Errorf("unsupported map strategy %q: %s", strategy, field.field.Name)
+	}
+}
+
+// resolveMapValue returns a wrapper for the value type of a map field.
+func (b *MappingPropertiesBuilder) resolveMapValue(wrapper *fieldWrapper) *fieldWrapper {
+	valueType := wrapper.value.Type().Elem()
+	if valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+	return &fieldWrapper{
+		field:       wrapper.field,
+		tags:        wrapper.tags,
+		kind:        valueType.Kind(),
+		value:       reflect.New(valueType).Elem(),
+		isPrimitive: b.isPrimitive(valueType.Kind()),
+	}
+}
+
+// addObjectProperties adds the properties that apply to object and nested fields.
+func (b *MappingPropertiesBuilder) addObjectProperties(
+	resolvedField *fieldWrapper,
+	mappingProperty *MappingProperty,
+) error {
+	for _, opt := range []struct {
+		key    string
+		val    string
+		target **bool
+	}{
+		{key: tagOptionIncludeInParent, val: resolvedField.tags.includeInParent, target: &mappingProperty.IncludeInParent},
+		{key: tagOptionIncludeInRoot, val: resolvedField.tags.includeInRoot, target: &mappingProperty.IncludeInRoot},
+	} {
+		if opt.val == "" {
+			continue
+		}
+		if mappingProperty.FieldType != fieldTypeNested {
+			return fmt.Errorf("%s can only be set on nested fields: %s", opt.key, resolvedField.field.Name)
+		}
+		boolVal, err := strconv.ParseBool(opt.val)
+		if err != nil {
+			return errors.Wrapf(err, "strconv.ParseBool %s", opt.key)
+		}
+		*opt.target = MakePtr(boolVal)
+	}
 	return nil
 }
 
+func isObjectFieldType(fieldType string) bool {
+	return fieldType == fieldTypeObject || fieldType == fieldTypeNested
+}
+
 func validateField(field *fieldWrapper) error {
 	if field.kind == reflect.Struct {
 		switch field.value.Interface().(type) {
@@ -150,10 +506,13 @@ func validateField(field *fieldWrapper) error {
 }
 
 func (b *MappingPropertiesBuilder) resolveFieldType(field *fieldWrapper) (string, error) {
-	fieldTypeOverride := getTagOptionValue(field.field, tagKey, tagOptionType)
+	fieldTypeOverride := field.tags.fieldType
 	if fieldTypeOverride != "" {
 		return fieldTypeOverride, nil
 	}
+	if provider, ok := getTypeProvider(field.value.Type()); ok {
+		return provider.GetOpenSearchFieldType(), nil
+	}
 	if field.isPrimitive {
 		return b.getDefaultOSTypeFromPrimitiveKind(field.kind), nil
 	}
@@ -163,15 +522,24 @@ func (b *MappingPropertiesBuilder) resolveFieldType(field *fieldWrapper) (string
 				return "date", nil
 			}
 		}
+		if field.isSlice && b.optionContainer.nestedStructSlices {
+			return fieldTypeNested, nil
+		}
 	}
 	return "", nil
 }
 
 func (b *MappingPropertiesBuilder) resolveFieldFormat(field *fieldWrapper) (*string, error) {
-	fieldFormatOverride := getTagOptionValue(field.field, tagKey, tagOptionFormat)
+	fieldFormatOverride := field.tags.format
 	if fieldFormatOverride != "" {
 		return &fieldFormatOverride, nil
 	}
+	if provider, ok := getTypeProvider(field.value.Type()); ok {
+		if format := provider.GetOpenSearchFieldFormat(); format != "" {
+			return MakePtr(format), nil
+		}
+		return nil, nil
+	}
 	if field.kind == reflect.Struct {
 		if x, ok := field.value.Interface().(OpenSearchDateType); ok {
 			if x.GetOpenSearchDateFieldType() != "" {
@@ -182,9 +550,17 @@ func (b *MappingPropertiesBuilder) resolveFieldFormat(field *fieldWrapper) (*str
 	return nil, nil
 }
 
+// getTypeProvider returns an OpenSearchTypeProvider for the type if the type or a pointer to it implements it.
+func getTypeProvider(t reflect.Type) (OpenSearchTypeProvider, bool) {
+	if !reflect.PtrTo(t).Implements(reflect.TypeOf((*OpenSearchTypeProvider)(nil)).Elem()) {
+		return nil, false
+	}
+	return reflect.New(t).Interface().(OpenSearchTypeProvider), true
+}
+
 // resolveField returns a wrapper object for the given field. If the field is a pointer, it returns a wrapper
 // for the dereferenced field, since we treat both pointer and value fields the same.
-func (b *MappingPropertiesBuilder) resolveField(structField reflect.StructField, value reflect.Value) *fieldWrapper {
+func (b *MappingPropertiesBuilder) resolveField(structField reflect.StructField) *fieldWrapper {
 	var kind reflect.Kind
 	var val reflect.Value
 	if structField.Type.Kind() == reflect.Ptr {
@@ -192,11 +568,12 @@ func (b *MappingPropertiesBuilder) resolveField(structField reflect.StructField,
 		val = reflect.New(structField.Type.Elem()).Elem()
 	} else {
 		kind = structField.Type.Kind()
-		val = value
+		val = reflect.New(structField.Type).Elem()
 	}
 
 	return &fieldWrapper{
 		field:       structField,
+		tags:        parseFieldTags(structField),
 		kind:        kind,
 		value:       val,
 		isPrimitive: b.isPrimitive(kind),
@@ -210,7 +587,7 @@ func (b *MappingPropertiesBuilder) unslice(wrapper *fieldWrapper) *fieldWrapper
 		return wrapper
 	}
 
-	elemType := wrapper.field.Type.Elem()
+	elemType := wrapper.value.Type().Elem()
 	var (
 		newKind reflect.Kind
 		newVal  reflect.Value
@@ -225,9 +602,11 @@ func (b *MappingPropertiesBuilder) unslice(wrapper *fieldWrapper) *fieldWrapper
 
 	return &fieldWrapper{
 		field:       wrapper.field,
+		tags:        wrapper.tags,
 		kind:        newKind,
 		value:       newVal,
 		isPrimitive: b.isPrimitive(newKind),
+		isSlice:     true,
 	}
 }
 
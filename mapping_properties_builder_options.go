@@ -9,6 +9,11 @@ type mappingPropertiesBuilderOptionContainer struct {
 	omitUnsupportedTypes bool
 	fieldNameTransformer FieldNameTransformer
 	jsonFormatter        JsonFormatter
+	useJsonTags          bool
+	nestedStructSlices   bool
+	keywordSubField      *uint32 // ignore_above of the sub-field
+	mapStrategy          MapStrategy
+	collectAllErrors     bool
 }
 
 // MaxDepth option
@@ -48,3 +53,80 @@ func (c skipUnsupportedTypesOption) apply(opts *mappingPropertiesBuilderOptionCo
 func OmitUnsupportedTypes() MappingPropertiesBuilderOption {
 	return skipUnsupportedTypesOption(true)
 }
+
+// UseJsonTags option
+type useJsonTagsOption bool
+
+func (c useJsonTagsOption) apply(opts *mappingPropertiesBuilderOptionContainer) {
+	opts.useJsonTags = bool(c)
+}
+
+// WithJsonTagFieldNames makes the builder name properties after the `json` struct tags and skip fields that
+// encoding/json skips, so that the mapping matches documents marshalled with encoding/json. Fields without a json tag
+// name are named by the FieldNameTransformer.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithJsonTagFieldNames() MappingPropertiesBuilderOption {
+	return useJsonTagsOption(true)
+}
+
+// NestedStructSlices option
+type nestedStructSlicesOption bool
+
+func (c nestedStructSlicesOption) apply(opts *mappingPropertiesBuilderOptionContainer) {
+	opts.nestedStructSlices = bool(c)
+}
+
+// WithNestedStructSlices makes the builder map slices of structs as the "nested" type, so that each object of the
+// array is indexed as a separate document and can be queried independently. Without this option, only slices tagged
+// with "type:nested" are nested.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithNestedStructSlices() MappingPropertiesBuilderOption {
+	return nestedStructSlicesOption(true)
+}
+
+// KeywordSubField option
+type keywordSubFieldOption uint32
+
+func (c keywordSubFieldOption) apply(opts *mappingPropertiesBuilderOptionContainer) {
+	opts.keywordSubField = MakePtr(uint32(c))
+}
+
+// WithKeywordSubField adds a multi-field "keyword" of type "keyword" with the given ignore_above to every "text"
+// field that does not define a sub-field with that name itself. This is how OpenSearch maps dynamic string fields.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithKeywordSubField(ignoreAbove uint32) MappingPropertiesBuilderOption {
+	return keywordSubFieldOption(ignoreAbove)
+}
+
+// MapStrategy option
+type mapStrategyOption MapStrategy
+
+func (c mapStrategyOption) apply(opts *mappingPropertiesBuilderOptionContainer) {
+	opts.mapStrategy = MapStrategy(c)
+}
+
+// WithMapStrategy sets how fields of map types are mapped, unless overridden with a tag option like
+// "map:flat_object". Without a strategy, map fields are unsupported.
+//
+//goland:noinspection GoUnusedExportedFunction
+func WithMapStrategy(strategy MapStrategy) MappingPropertiesBuilderOption {
+	return mapStrategyOption(strategy)
+}
+
+// CollectAllErrors option
+type collectAllErrorsOption bool
+
+func (c collectAllErrorsOption) apply(opts *mappingPropertiesBuilderOptionContainer) {
+	opts.collectAllErrors = bool(c)
+}
+
+// CollectAllErrors makes the builder go on after a field it cannot map and report the problems of all fields at once,
+// in a BuildErrors error.
+//
+//goland:noinspection GoUnusedExportedFunction
+func CollectAllErrors() MappingPropertiesBuilderOption {
+	return collectAllErrorsOption(true)
+}
@@ -1,7 +1,9 @@
 package opensearchutil
 
 import (
+	"encoding/json"
 	"errors"
+	"reflect"
 	"testing"
 	"time"
 
@@ -419,3 +421,657 @@ func TestMappingPropertiesBuilder_BuildMappingProperties_SliceRecursiveMaxDepth(
 		g.Expect(mp.GetDepth() <= depth).To(gomega.BeTrue())
 	}
 }
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_UsesJsonTagNames(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		Title     string `json:"headline" opensearch:"type:keyword"`
+		CreatedAt string `json:",omitempty"`
+		Internal  string `json:"-"`
+		NoTag     string
+		private   string
+	}
+
+	builder := NewMappingPropertiesBuilder(WithJsonTagFieldNames())
+	mps, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "headline", FieldType: "keyword"},
+		{FieldName: "created_at", FieldType: "text"},
+		{FieldName: "no_tag", FieldType: "text"},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_FlattensEmbeddedStructs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type audit struct {
+		CreatedAt TimeBasicDateTime
+	}
+	type baseEntity struct {
+		ID string `opensearch:"type:keyword"`
+		*audit
+	}
+	type doc struct {
+		baseEntity
+		Title string
+	}
+
+	builder := NewMappingPropertiesBuilder()
+	mps, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "id", FieldType: "keyword"},
+		{FieldName: "created_at", FieldType: "date", FieldFormat: MakePtr("basic_date_time")},
+		{FieldName: "title", FieldType: "text"},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_EmbeddedFieldsAreShadowed(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type a struct {
+		Name  string
+		Email string
+	}
+	type b struct {
+		Email string
+		Code  string
+	}
+	type doc struct {
+		a
+		b
+		Name string `opensearch:"type:keyword"`
+	}
+
+	builder := NewMappingPropertiesBuilder()
+	mps, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+	// Name is shadowed by the outer field, the conflicting Email fields of the same depth cancel out.
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "code", FieldType: "text"},
+		{FieldName: "name", FieldType: "keyword"},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_JsonTaggedEmbeddedFieldWinsConflict(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type A struct {
+		Email string `json:"email" opensearch:"type:keyword"`
+	}
+	type B struct {
+		Email string
+	}
+	type doc struct {
+		A
+		B
+	}
+
+	builder := NewMappingPropertiesBuilder(WithJsonTagFieldNames())
+	mps, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "email", FieldType: "keyword"},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_SameStructEmbeddedTwiceAtSameDepthCancelsOut(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type Base struct {
+		ID string `opensearch:"type:keyword"`
+	}
+	type A struct {
+		Base
+		Name string
+	}
+	type B struct {
+		Base
+		Code string
+	}
+	type doc struct {
+		A
+		B
+	}
+
+	builder := NewMappingPropertiesBuilder(WithJsonTagFieldNames())
+	mps, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+	// encoding/json drops ID as ambiguous, as both A and B promote it from depth 2
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "name", FieldType: "text"},
+		{FieldName: "code", FieldType: "text"},
+	}))
+	jsonBytes, err := json.Marshal(doc{A: A{Base: Base{ID: "1"}}, B: B{Base: Base{ID: "2"}}})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(jsonBytes)).To(gomega.MatchJSON(`{"Name": "", "Code": ""}`))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_KeepsJsonNamedEmbeddedStructsWithJsonTagTransformer(
+	t *testing.T,
+) {
+	g := gomega.NewGomegaWithT(t)
+
+	type Meta struct {
+		Source string `json:"source" opensearch:"type:keyword"`
+	}
+	type doc struct {
+		Meta  `json:"meta"`
+		Title string `json:"title"`
+	}
+
+	builder := NewMappingPropertiesBuilder(WithFieldNameTransformer(NewJsonTagFieldNameTransformer(nil)))
+	mps, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "meta", Children: []MappingProperty{{FieldName: "source", FieldType: "keyword"}}},
+		{FieldName: "title", FieldType: "text"},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_KeepsEmbeddedStructsWhenOptedOut(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type Base struct {
+		ID string `opensearch:"type:keyword"`
+	}
+	type Meta struct {
+		Source string `opensearch:"type:keyword"`
+	}
+	type doc struct {
+		Base `opensearch:"flatten:false"`
+		Meta `json:"meta"`
+	}
+
+	builder := NewMappingPropertiesBuilder(WithJsonTagFieldNames())
+	mps, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "base", Children: []MappingProperty{{FieldName: "id", FieldType: "keyword"}}},
+		{FieldName: "meta", Children: []MappingProperty{{FieldName: "source", FieldType: "keyword"}}},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_MapsTaggedStructSliceAsNested(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type address struct {
+		City string `opensearch:"type:keyword"`
+	}
+	type person struct {
+		Addresses []address `opensearch:"type:nested,include_in_parent:true"`
+		Previous  []address
+	}
+
+	builder := NewMappingPropertiesBuilder()
+	mps, err := builder.BuildMappingProperties(person{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{
+			FieldName:       "addresses",
+			FieldType:       "nested",
+			IncludeInParent: MakePtr(true),
+			Children:        []MappingProperty{{FieldName: "city", FieldType: "keyword"}},
+		},
+		{
+			FieldName: "previous",
+			Children:  []MappingProperty{{FieldName: "city", FieldType: "keyword"}},
+		},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_MapsAllStructSlicesAsNestedWithOption(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type address struct {
+		City string `opensearch:"type:keyword"`
+	}
+	type person struct {
+		Addresses []*address
+		Home      address
+		Aliases   []string
+		Dates     []TimeBasicDate
+	}
+
+	builder := NewMappingPropertiesBuilder(WithNestedStructSlices())
+	mps, err := builder.BuildMappingProperties(person{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{
+			FieldName: "addresses",
+			FieldType: "nested",
+			Children:  []MappingProperty{{FieldName: "city", FieldType: "keyword"}},
+		},
+		{
+			FieldName: "home",
+			Children:  []MappingProperty{{FieldName: "city", FieldType: "keyword"}},
+		},
+		{FieldName: "aliases", FieldType: "text"},
+		{FieldName: "dates", FieldType: "date", FieldFormat: MakePtr("basic_date")},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_ErrorsOnIncludeInParentForNonNested(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type address struct {
+		City string
+	}
+	type person struct {
+		Home address `opensearch:"include_in_parent:true"`
+	}
+
+	builder := NewMappingPropertiesBuilder()
+	_, err := builder.BuildMappingProperties(person{})
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.ContainSubstring("can only be set on nested fields"))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_SetsMultiFields(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		Name  string `opensearch:"fields.raw:type=keyword;ignore_above=256;normalizer=lowercase"`
+		Title string `opensearch:"type:text, analyzer:standard, fields.english:type=text;analyzer=english, fields.raw:"`
+		Code  string `opensearch:"type:keyword,normalizer:lowercase,ignore_above:64"`
+	}
+
+	builder := NewMappingPropertiesBuilder()
+	mps, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{
+			FieldName: "name",
+			FieldType: "text",
+			Fields: []MappingProperty{
+				{
+					FieldName:   "raw",
+					FieldType:   "keyword",
+					IgnoreAbove: MakePtr(uint32(256)),
+					Normalizer:  MakePtr("lowercase"),
+				},
+			},
+		},
+		{
+			FieldName: "title",
+			FieldType: "text",
+			Analyzer:  MakePtr("standard"),
+			Fields: []MappingProperty{
+				{FieldName: "english", FieldType: "text", Analyzer: MakePtr("english")},
+				{FieldName: "raw", FieldType: "keyword"},
+			},
+		},
+		{
+			FieldName:   "code",
+			FieldType:   "keyword",
+			Normalizer:  MakePtr("lowercase"),
+			IgnoreAbove: MakePtr(uint32(64)),
+		},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_ErrorsOnUnknownMultiFieldOption(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		Name string `opensearch:"fields.raw:type=keyword;boost=2"`
+	}
+
+	builder := NewMappingPropertiesBuilder()
+	_, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.ContainSubstring("unsupported multi-field option: boost"))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_AddsKeywordSubFieldWithOption(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		Name  string
+		Tags  []string
+		Email string `opensearch:"type:keyword"`
+		Title string `opensearch:"fields.keyword:type=keyword;ignore_above=10"`
+	}
+
+	builder := NewMappingPropertiesBuilder(WithKeywordSubField(256))
+	mps, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+
+	keywordSubField := []MappingProperty{
+		{FieldName: "keyword", FieldType: "keyword", IgnoreAbove: MakePtr(uint32(256))},
+	}
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "name", FieldType: "text", Fields: keywordSubField},
+		{FieldName: "tags", FieldType: "text", Fields: keywordSubField},
+		{FieldName: "email", FieldType: "keyword"},
+		{
+			FieldName: "title",
+			FieldType: "text",
+			Fields: []MappingProperty{
+				{FieldName: "keyword", FieldType: "keyword", IgnoreAbove: MakePtr(uint32(10))},
+			},
+		},
+	}))
+}
+
+type testIPAddress [4]byte
+
+func (a testIPAddress) GetOpenSearchFieldType() string {
+	return "ip"
+}
+
+func (a testIPAddress) GetOpenSearchFieldFormat() string {
+	return ""
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_UsesOpenSearchTypeProvider(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		UpdatedAt NumericTime
+		DeletedAt *NumericTime
+		CreatedAt NumericTimeDate
+		ClientIP  testIPAddress
+		History   []NumericTime
+		Formatted NumericTimeDate `opensearch:"format:epoch_second||epoch_millis"`
+	}
+
+	builder := NewMappingPropertiesBuilder()
+	mps, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "updated_at", FieldType: "long"},
+		{FieldName: "deleted_at", FieldType: "long"},
+		{FieldName: "created_at", FieldType: "date", FieldFormat: MakePtr("epoch_second")},
+		{FieldName: "client_ip", FieldType: "ip"},
+		{FieldName: "history", FieldType: "long"},
+		{FieldName: "formatted", FieldType: "date", FieldFormat: MakePtr("epoch_second||epoch_millis")},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_AcceptsPointersAndTypes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type person struct {
+		Name string
+	}
+	expected := []MappingProperty{{FieldName: "name", FieldType: "text"}}
+
+	var nilPerson *person
+	var iface interface{} = &person{}
+	builder := NewMappingPropertiesBuilder()
+	for _, input := range []interface{}{
+		&person{},
+		nilPerson,
+		iface,
+		reflect.TypeOf(person{}),
+		reflect.TypeOf(&person{}),
+	} {
+		mps, err := builder.BuildMappingProperties(input)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(mps).To(gomega.Equal(expected))
+	}
+
+	mps, err := BuildMappingPropertiesFor[person]()
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal(expected))
+	_, cached := defaultMappingPropertiesBuilder.cache.Load(reflect.TypeOf(person{}))
+	g.Expect(cached).To(gomega.BeTrue())
+
+	mps, err = BuildMappingPropertiesFor[*person](WithMaxDepth(1))
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal(expected))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_ErrorsWithNonStructInput(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	builder := NewMappingPropertiesBuilder()
+	for _, input := range []interface{}{
+		nil,
+		"foo",
+		MakePtr(1),
+		[]struct{}{},
+		reflect.TypeOf((*error)(nil)).Elem(),
+	} {
+		_, err := builder.BuildMappingProperties(input)
+		var invalidInputErr *InvalidInputError
+		g.Expect(errors.As(err, &invalidInputErr)).To(gomega.BeTrue(), "%v", input)
+	}
+
+	_, err := BuildMappingPropertiesFor[int]()
+	var invalidInputErr *InvalidInputError
+	g.Expect(errors.As(err, &invalidInputErr)).To(gomega.BeTrue())
+	g.Expect(invalidInputErr.Type).To(gomega.Equal(reflect.TypeOf(0)))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_MapsMapFieldsWithStrategies(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		Labels  map[string]string   `opensearch:"map:flat_object"`
+		Extra   map[string]string   `opensearch:"map:object"`
+		Metrics map[string]float64  `opensearch:"map:dynamic_template"`
+		Tags    map[string][]string `opensearch:"map:dynamic_template,type:keyword,ignore_above:100"`
+		Flat    map[string]int      `opensearch:"type:flat_object"`
+	}
+
+	builder := NewMappingPropertiesBuilder()
+	mps, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "labels", FieldType: "flat_object"},
+		{FieldName: "extra", FieldType: "object", Dynamic: MakePtr("true")},
+		{
+			FieldName:       "metrics",
+			FieldType:       "object",
+			Dynamic:         MakePtr("true"),
+			DynamicTemplate: &MappingProperty{FieldName: "metrics", FieldType: "float"},
+		},
+		{
+			FieldName: "tags",
+			FieldType: "object",
+			Dynamic:   MakePtr("true"),
+			DynamicTemplate: &MappingProperty{
+				FieldName:   "tags",
+				FieldType:   "keyword",
+				IgnoreAbove: MakePtr(uint32(100)),
+			},
+		},
+		{FieldName: "flat", FieldType: "flat_object"},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_MapsMapFieldsWithDefaultStrategy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		Labels map[string]string
+		Extra  map[string]string `opensearch:"map:object"`
+	}
+
+	_, err := NewMappingPropertiesBuilder().BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.ContainSubstring("field not supported: Labels"))
+
+	mps, err := NewMappingPropertiesBuilder(WithMapStrategy(MapStrategyFlatObject)).BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal([]MappingProperty{
+		{FieldName: "labels", FieldType: "flat_object"},
+		{FieldName: "extra", FieldType: "object", Dynamic: MakePtr("true")},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_ErrorsOnDynamicTemplateOfStructs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type value struct {
+		Name string
+	}
+	type doc struct {
+		Values map[string]value `opensearch:"map:dynamic_template"`
+	}
+
+	_, err := NewMappingPropertiesBuilder().BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.ContainSubstring("must map to a field type"))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_ReportsFieldPathsInErrors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type Product struct {
+		Attrs chan int
+	}
+	type Line struct {
+		Product Product
+	}
+	type Order struct {
+		Lines []*Line
+	}
+
+	_, err := NewMappingPropertiesBuilder().BuildMappingProperties(Order{})
+	var buildErr *BuildError
+	g.Expect(errors.As(err, &buildErr)).To(gomega.BeTrue())
+	g.Expect(buildErr.GoPath).To(gomega.Equal("Order.Lines[].Product.Attrs"))
+	g.Expect(buildErr.Path).To(gomega.Equal("lines.product.attrs"))
+	g.Expect(err.Error()).To(gomega.HavePrefix("Order.Lines[].Product.Attrs (lines.product.attrs): field not supported"))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_CollectsAllErrors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type Meta struct {
+		Created time.Time
+		Source  string `opensearch:"ignore_above:lots"`
+	}
+	type Doc struct {
+		Callback func()
+		Meta     Meta
+		Title    string
+	}
+
+	_, err := NewMappingPropertiesBuilder(CollectAllErrors()).BuildMappingProperties(Doc{})
+	var buildErrs BuildErrors
+	g.Expect(errors.As(err, &buildErrs)).To(gomega.BeTrue())
+	g.Expect(buildErrs).To(gomega.HaveLen(3))
+	g.Expect(buildErrs[0].GoPath).To(gomega.Equal("Doc.Callback"))
+	g.Expect(buildErrs[1].GoPath).To(gomega.Equal("Doc.Meta.Created"))
+	g.Expect(buildErrs[1].Path).To(gomega.Equal("meta.created"))
+	g.Expect(buildErrs[1].Err).To(gomega.Equal(ErrGotBuiltInTimeField))
+	g.Expect(buildErrs[2].GoPath).To(gomega.Equal("Doc.Meta.Source"))
+	g.Expect(errors.Is(err, ErrGotBuiltInTimeField)).To(gomega.BeTrue())
+
+	var buildErr *BuildError
+	g.Expect(errors.As(err, &buildErr)).To(gomega.BeTrue())
+	g.Expect(buildErr).To(gomega.BeIdenticalTo(buildErrs[0]))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_CachedResultsAreCopies(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type doc struct {
+		Title string `opensearch:"analyzer:standard,copy_to:all_text,fields.raw:"`
+		Meta  struct {
+			Source string
+		}
+	}
+
+	builder := NewMappingPropertiesBuilder()
+	first, err := builder.BuildMappingProperties(doc{})
+	g.Expect(err).To(gomega.BeNil())
+
+	*first[0].Analyzer = "english"
+	first[0].CopyTo[0] = "changed"
+	first[0].Fields[0].FieldName = "changed"
+	first[1].Children[0].FieldType = "changed"
+
+	second, err := builder.BuildMappingProperties(&doc{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(second).To(gomega.Equal([]MappingProperty{
+		{
+			FieldName: "title",
+			FieldType: "text",
+			Analyzer:  MakePtr("standard"),
+			CopyTo:    []string{"all_text"},
+			Fields:    []MappingProperty{{FieldName: "raw", FieldType: "keyword"}},
+		},
+		{
+			FieldName: "meta",
+			Children:  []MappingProperty{{FieldName: "source", FieldType: "text"}},
+		},
+	}))
+}
+
+func TestMappingPropertiesBuilder_BuildMappingProperties_IsSafeForConcurrentUse(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type a struct {
+		Name string
+	}
+	type b struct {
+		Age int
+	}
+
+	builder := NewMappingPropertiesBuilder()
+	results := make(chan []MappingProperty, 20)
+	for i := 0; i < cap(results); i++ {
+		go func(i int) {
+			var obj interface{} = a{}
+			if i%2 == 1 {
+				obj = b{}
+			}
+			mps, err := builder.BuildMappingProperties(obj)
+			if err != nil {
+				mps = nil
+			}
+			results <- mps
+		}(i)
+	}
+	for i := 0; i < cap(results); i++ {
+		g.Expect(<-results).To(gomega.Or(
+			gomega.Equal([]MappingProperty{{FieldName: "name", FieldType: "text"}}),
+			gomega.Equal([]MappingProperty{{FieldName: "age", FieldType: "integer"}}),
+		))
+	}
+}
+
+type benchmarkAddress struct {
+	Street  string `opensearch:"type:text,fields.raw:type=keyword;ignore_above=256"`
+	City    string `opensearch:"type:keyword"`
+	Country string `opensearch:"type:keyword,normalizer:lowercase"`
+}
+
+type benchmarkDoc struct {
+	ID          string `opensearch:"type:keyword"`
+	Title       string `opensearch:"type:text,analyzer:standard,search_analyzer:english,copy_to:all_text"`
+	Description string `opensearch:"index_prefixes:min_chars=2;max_chars=10"`
+	AllText     string
+	CreatedAt   TimeBasicDateTime
+	UpdatedAt   NumericTime
+	Price       float64
+	Quantity    int
+	Tags        []string          `opensearch:"type:keyword"`
+	Labels      map[string]string `opensearch:"map:flat_object"`
+	Home        benchmarkAddress
+	Addresses   []benchmarkAddress `opensearch:"type:nested"`
+}
+
+func BenchmarkMappingPropertiesBuilder_BuildMappingProperties_Uncached(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := NewMappingPropertiesBuilder().BuildMappingProperties(benchmarkDoc{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMappingPropertiesBuilder_BuildMappingProperties_Cached(b *testing.B) {
+	builder := NewMappingPropertiesBuilder()
+	for i := 0; i < b.N; i++ {
+		if _, err := builder.BuildMappingProperties(benchmarkDoc{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
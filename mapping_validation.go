@@ -0,0 +1,186 @@
+package opensearchutil
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidateMapping runs all validations of mapping properties: ValidateAnalysisReferences and ValidateCopyTo. settings
+// can be nil. All problems are reported in a single FieldErrors error.
+func ValidateMapping(mappingProperties []MappingProperty, settings *IndexSettings) error {
+	var errs FieldErrors
+	for _, err := range []error{
+		ValidateAnalysisReferences(mappingProperties, settings),
+		ValidateCopyTo(mappingProperties),
+	} {
+		if fieldErrs, ok := err.(FieldErrors); ok {
+			errs = append(errs, fieldErrs...)
+		}
+	}
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool {
+			return errs[i].Path < errs[j].Path
+		})
+		return errs
+	}
+	return nil
+}
+
+// ValidateCopyTo checks the copy_to destinations of the mapping properties: every destination must be an existing
+// field given by its full dotted path, must not be an object or a multi-field, and fields must not copy to each other
+// in a cycle. All problems are reported in a single FieldErrors error.
+func ValidateCopyTo(mappingProperties []MappingProperty) error {
+	type target struct {
+		mp           MappingProperty
+		isMultiField bool
+	}
+	targets := map[string]target{}
+	var collect func(prefix string, mps []MappingProperty, isMultiField bool)
+	collect = func(prefix string, mps []MappingProperty, isMultiField bool) {
+		for _, mp := range mps {
+			path := prefix + mp.FieldName
+			targets[path] = target{mp: mp, isMultiField: isMultiField}
+			collect(path+".", mp.Fields, true)
+			collect(path+".", mp.Children, false)
+		}
+	}
+	collect("", mappingProperties, false)
+
+	var errs FieldErrors
+	edges := map[string][]string{}
+	walkMappingProperties(mappingProperties, func(path string, mp MappingProperty) {
+		for _, dest := range mp.CopyTo {
+			t, ok := targets[dest]
+			switch {
+			case !ok:
+				errs = append(errs, &FieldError{
+					Path:   path,
+					Reason: fmt.Sprintf("copy_to destination %q does not exist", dest),
+				})
+			case t.mp.Children != nil || isObjectFieldType(t.mp.FieldType):
+				errs = append(errs, &FieldError{
+					Path:   path,
+					Reason: fmt.Sprintf("copy_to destination %q is an object field", dest),
+				})
+			case t.isMultiField:
+				errs = append(errs, &FieldError{
+					Path:   path,
+					Reason: fmt.Sprintf("copy_to destination %q is a multi-field", dest),
+				})
+			case dest == path:
+				errs = append(errs, &FieldError{
+					Path:   path,
+					Reason: "copy_to destination is the field itself",
+				})
+			default:
+				edges[path] = append(edges[path], dest)
+			}
+		}
+	})
+
+	for _, cycle := range findCopyToCycles(edges) {
+		errs = append(errs, &FieldError{
+			Path:   cycle[0],
+			Reason: "copy_to cycle: " + strings.Join(append(cycle, cycle[0]), " -> "),
+		})
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// findCopyToCycles finds the cycles in a graph of copy_to edges. Each cycle is reported once, starting at its
+// lexicographically smallest path.
+func findCopyToCycles(edges map[string][]string) [][]string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := map[string]int{}
+	var stack []string
+	seen := map[string]bool{}
+	var cycles [][]string
+
+	var visit func(path string)
+	visit = func(path string) {
+		state[path] = inProgress
+		stack = append(stack, path)
+		for _, dest := range edges[path] {
+			switch state[dest] {
+			case unvisited:
+				visit(dest)
+			case inProgress:
+				var cycle []string
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == dest {
+						cycle = append([]string{}, stack[i:]...)
+						break
+					}
+				}
+				cycle = rotateToSmallest(cycle)
+				if key := strings.Join(cycle, "\x00"); !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, cycle)
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[path] = done
+	}
+
+	sources := make([]string, 0, len(edges))
+	for path := range edges {
+		sources = append(sources, path)
+	}
+	sort.Strings(sources)
+	for _, path := range sources {
+		if state[path] == unvisited {
+			visit(path)
+		}
+	}
+	return cycles
+}
+
+func rotateToSmallest(cycle []string) []string {
+	smallest := 0
+	for i, path := range cycle {
+		if path < cycle[smallest] {
+			smallest = i
+		}
+	}
+	return append(append([]string{}, cycle[smallest:]...), cycle[:smallest]...)
+}
+
+// walkMappingProperties calls fn for every property in the tree, including multi-fields and the values of dynamic
+// templates, with its dotted path. Properties are visited in the order of their dotted paths.
+func walkMappingProperties(mappingProperties []MappingProperty, fn func(path string, mp MappingProperty)) {
+	type entry struct {
+		path string
+		mp   MappingProperty
+	}
+	var entries []entry
+	var collect func(prefix string, mps []MappingProperty)
+	collect = func(prefix string, mps []MappingProperty) {
+		for _, mp := range mps {
+			path := prefix + mp.FieldName
+			entries = append(entries, entry{path: path, mp: mp})
+			if mp.DynamicTemplate != nil {
+				entries = append(entries, entry{path: path + ".*", mp: *mp.DynamicTemplate})
+			}
+			collect(path+".", mp.Fields)
+			collect(path+".", mp.Children)
+		}
+	}
+	collect("", mappingProperties)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].path < entries[j].path
+	})
+	for _, e := range entries {
+		fn(e.path, e.mp)
+	}
+}
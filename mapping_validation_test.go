@@ -0,0 +1,78 @@
+package opensearchutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestValidateCopyTo_AcceptsValidDestinations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mps := []MappingProperty{
+		{FieldName: "title", FieldType: "text", CopyTo: []string{"all_text", "meta.search"}},
+		{FieldName: "all_text", FieldType: "text"},
+		{FieldName: "meta", Children: []MappingProperty{{FieldName: "search", FieldType: "text"}}},
+	}
+	g.Expect(ValidateCopyTo(mps)).To(gomega.Succeed())
+}
+
+func TestValidateCopyTo_ReportsAllProblems(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mps := []MappingProperty{
+		{
+			FieldName: "title",
+			FieldType: "text",
+			CopyTo:    []string{"all_txt", "meta", "name.raw"},
+			Fields:    []MappingProperty{{FieldName: "raw", FieldType: "keyword"}},
+		},
+		{
+			FieldName: "name",
+			FieldType: "text",
+			CopyTo:    []string{"meta.a"},
+			Fields:    []MappingProperty{{FieldName: "raw", FieldType: "keyword"}},
+		},
+		{
+			FieldName: "meta",
+			Children: []MappingProperty{
+				{FieldName: "a", FieldType: "text", CopyTo: []string{"meta.b"}},
+				{FieldName: "b", FieldType: "text", CopyTo: []string{"meta.a", "meta.b"}},
+			},
+		},
+	}
+
+	err := ValidateCopyTo(mps)
+	var fieldErrs FieldErrors
+	g.Expect(errors.As(err, &fieldErrs)).To(gomega.BeTrue())
+	g.Expect(fieldErrs).To(gomega.Equal(FieldErrors{
+		{Path: "meta.b", Reason: "copy_to destination is the field itself"},
+		{Path: "title", Reason: `copy_to destination "all_txt" does not exist`},
+		{Path: "title", Reason: `copy_to destination "meta" is an object field`},
+		{Path: "title", Reason: `copy_to destination "name.raw" is a multi-field`},
+		{Path: "meta.a", Reason: "copy_to cycle: meta.a -> meta.b -> meta.a"},
+	}))
+	g.Expect(err.Error()).To(gomega.ContainSubstring(`title: copy_to destination "all_txt" does not exist; `))
+}
+
+func TestValidateMapping_CombinesValidations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mps := []MappingProperty{
+		{FieldName: "title", FieldType: "text", Analyzer: MakePtr("nope"), CopyTo: []string{"all_txt"}},
+		{FieldName: "all_text", FieldType: "text"},
+	}
+
+	err := ValidateMapping(mps, nil)
+	g.Expect(err).To(gomega.BeAssignableToTypeOf(FieldErrors{}))
+	g.Expect(err.(FieldErrors)).To(gomega.HaveLen(2))
+	g.Expect(err.(FieldErrors)[0].Path).To(gomega.Equal("title"))
+	g.Expect(err.(FieldErrors)[0].Reason).To(gomega.ContainSubstring(`analyzer "nope"`))
+	g.Expect(err.(FieldErrors)[1]).To(gomega.Equal(&FieldError{
+		Path:   "title",
+		Reason: `copy_to destination "all_txt" does not exist`,
+	}))
+
+	g.Expect(ValidateMapping(mps[1:], nil)).To(gomega.Succeed())
+}
@@ -2,34 +2,86 @@ package opensearchutil
 
 import (
 	_ "embed"
+	"reflect"
 )
 
 const (
 	DefaultMaxDepth = 10
 
-	tagKey                  = "opensearch"
-	tagOptionType           = "type"
-	tagOptionFormat         = "format"
-	tagOptionAnalyzer       = "analyzer"
-	tagOptionSearchAnalyzer = "search_analyzer"
-	tagOptionCopyTo         = "copy_to"
-	tagOptionIndexPrefixes  = "index_prefixes"
+	tagKey                   = "opensearch"
+	tagOptionType            = "type"
+	tagOptionFormat          = "format"
+	tagOptionAnalyzer        = "analyzer"
+	tagOptionSearchAnalyzer  = "search_analyzer"
+	tagOptionCopyTo          = "copy_to"
+	tagOptionIndexPrefixes   = "index_prefixes"
+	tagOptionFlatten         = "flatten"
+	tagOptionIncludeInParent = "include_in_parent"
+	tagOptionIncludeInRoot   = "include_in_root"
+	tagOptionNormalizer      = "normalizer"
+	tagOptionIgnoreAbove     = "ignore_above"
+	tagOptionScalingFactor   = "scaling_factor"
+	// tagOptionFieldsPrefix starts options that define multi-fields, e.g. "fields.raw:type=keyword;ignore_above=256"
+	tagOptionFieldsPrefix = "fields."
+	tagOptionMap          = "map"
+	// tagFlagId marks the field that holds the document ID, e.g. for BulkEncoder
+	tagFlagId = "id"
+
+	fieldTypeObject  = "object"
+	fieldTypeNested  = "nested"
+	fieldTypeText    = "text"
+	fieldTypeKeyword = "keyword"
 )
 
+// tagFlags are the tag options given without a value.
+var tagFlags = map[string]bool{
+	tagFlagId: true,
+}
+
 // MappingProperty corresponds to mappings.properties of a mapping JSON.
 // MappingProperty defines either a primitive data type, in which case FieldType != "", or an object, in which case
-// len(Children) > 0.
+// len(Children) > 0. An object can have FieldType "nested" (or "object"), in which case IncludeInParent and
+// IncludeInRoot apply to it. An object with FieldType "object" can have no Children if it is dynamic.
+// Fields are multi-fields of a primitive data type: the same value indexed as a sub-field with another type or
+// analyzer, e.g. "name.raw" of type "keyword" for a "name" field of type "text".
 type MappingProperty struct {
 	FieldName      string
 	FieldType      string
 	FieldFormat    *string
 	Analyzer       *string
 	SearchAnalyzer *string
-	CopyTo         []string
-	IndexPrefixes  *map[string]string
-	Children       []MappingProperty
+	Normalizer     *string
+	IgnoreAbove    *uint32
+	// ScalingFactor is the scaling_factor of a "scaled_float" field
+	ScalingFactor   *float64
+	CopyTo          []string
+	IndexPrefixes   *map[string]string
+	IncludeInParent *bool
+	IncludeInRoot   *bool
+	Dynamic         *string
+	// DynamicTemplate is the mapping of the values of a map field mapped with MapStrategyDynamicTemplate. It is
+	// rendered into mappings.dynamic_templates, matching all fields under this property.
+	DynamicTemplate *MappingProperty
+	Fields          []MappingProperty
+	Children        []MappingProperty
 }
 
+// MapStrategy tells MappingPropertiesBuilder how to map fields of map types, such as map[string]string.
+type MapStrategy string
+
+const (
+	// MapStrategyFlatObject maps a map field as a "flat_object" field.
+	MapStrategyFlatObject MapStrategy = "flat_object"
+
+	// MapStrategyObject maps a map field as an object with "dynamic": true, so that OpenSearch maps its keys as they
+	// come.
+	MapStrategyObject MapStrategy = "object"
+
+	// MapStrategyDynamicTemplate maps a map field as an object with "dynamic": true, together with a dynamic template
+	// that maps every key of the map (fields matching "path.to.map.*") the way the map value type is mapped.
+	MapStrategyDynamicTemplate MapStrategy = "dynamic_template"
+)
+
 // IndexSettings allows to specify settings of an index, at its creation. This struct includes both static (those
 // that are specified at index increation) settings, and dynamic settings (those that can be altered after index
 // creation).
@@ -65,6 +117,16 @@ type IndexSettings struct {
 	GcDeletes                       *string `json:"gc_deletes,omitempty"`
 	DefaultPipeline                 *string `json:"default_pipeline,omitempty"`
 	FinalPipeline                   *string `json:"final_pipeline,omitempty"`
+
+	// Analysis defines custom analyzers, tokenizers, filters and normalizers
+	Analysis *Analysis `json:"analysis,omitempty"`
+}
+
+// OpenSearchTypeProvider tells MappingPropertiesBuilder the OpenSearch type and format of a custom type. A field of
+// such a type is mapped as a single property, even if the type is a struct. An empty format is left out.
+type OpenSearchTypeProvider interface {
+	GetOpenSearchFieldType() string
+	GetOpenSearchFieldFormat() string
 }
 
 type JsonFormatter interface {
@@ -75,6 +137,13 @@ type FieldNameTransformer interface {
 	TransformFieldName(name string) (string, error)
 }
 
+// StructFieldNameTransformer is a FieldNameTransformer that names a property given the whole struct field, e.g. based
+// on its tags. MappingPropertiesBuilder leaves fields for which skip is true out of the mapping.
+type StructFieldNameTransformer interface {
+	FieldNameTransformer
+	TransformStructField(field reflect.StructField) (name string, skip bool, err error)
+}
+
 func (p MappingProperty) GetDepth() int {
 	return getDepth(p)
 }
@@ -89,3 +158,51 @@ func getDepth(property MappingProperty) int {
 	}
 	return depth
 }
+
+// cloneMappingProperties makes a deep copy of mapping properties.
+func cloneMappingProperties(mps []MappingProperty) []MappingProperty {
+	if mps == nil {
+		return nil
+	}
+	clones := make([]MappingProperty, len(mps))
+	for i, mp := range mps {
+		clones[i] = mp.clone()
+	}
+	return clones
+}
+
+func (p MappingProperty) clone() MappingProperty {
+	c := p
+	c.FieldFormat = clonePtr(p.FieldFormat)
+	c.Analyzer = clonePtr(p.Analyzer)
+	c.SearchAnalyzer = clonePtr(p.SearchAnalyzer)
+	c.Normalizer = clonePtr(p.Normalizer)
+	c.IgnoreAbove = clonePtr(p.IgnoreAbove)
+	c.ScalingFactor = clonePtr(p.ScalingFactor)
+	c.IncludeInParent = clonePtr(p.IncludeInParent)
+	c.IncludeInRoot = clonePtr(p.IncludeInRoot)
+	c.Dynamic = clonePtr(p.Dynamic)
+	if p.CopyTo != nil {
+		c.CopyTo = append([]string{}, p.CopyTo...)
+	}
+	if p.IndexPrefixes != nil {
+		indexPrefixes := make(map[string]string, len(*p.IndexPrefixes))
+		for k, v := range *p.IndexPrefixes {
+			indexPrefixes[k] = v
+		}
+		c.IndexPrefixes = &indexPrefixes
+	}
+	if p.DynamicTemplate != nil {
+		c.DynamicTemplate = MakePtr(p.DynamicTemplate.clone())
+	}
+	c.Fields = cloneMappingProperties(p.Fields)
+	c.Children = cloneMappingProperties(p.Children)
+	return c
+}
+
+func clonePtr[V any](v *V) *V {
+	if v == nil {
+		return nil
+	}
+	return MakePtr(*v)
+}
@@ -0,0 +1,194 @@
+package opensearchutil
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// structField is a field of a struct, possibly promoted from an embedded struct, together with its property name.
+type structField struct {
+	field reflect.StructField // Index is the full index sequence from the outermost struct
+	name  string
+	// tagged is true when the name was given explicitly with a json tag, such fields win name conflicts with
+	// untagged fields at the same depth, the same as in encoding/json.
+	tagged bool
+}
+
+// collectFields returns the fields of struct type t that get mapped into properties. Fields of embedded structs
+// are promoted into t the way encoding/json promotes them: a field at a shallower depth shadows fields with the same
+// name at deeper depths, and fields with the same name at the same depth cancel each other out unless exactly one of
+// them is named with a json tag.
+func (b *MappingPropertiesBuilder) collectFields(t reflect.Type) ([]structField, error) {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	var fields []structField
+	var next []embedded
+	current := []embedded{{typ: t}}
+	// count is the number of times each struct type of the current depth is embedded at that depth, nextCount is the
+	// same for the next depth. A type embedded more than once at a depth has its fields added as many times, so that
+	// they conflict with each other.
+	count, nextCount := map[reflect.Type]int{t: 1}, map[reflect.Type]int{}
+	visited := map[reflect.Type]bool{}
+	for len(current) > 0 {
+		// Fields found at this depth by name, used to detect conflicts between fields of the same depth.
+		levelFields := map[string][]structField{}
+		var levelNames []string
+
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				f := e.typ.Field(i)
+				f.Index = append(append([]int{}, e.index...), i)
+
+				if b.shouldFlatten(f) {
+					ft := f.Type
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					nextCount[ft]++
+					if nextCount[ft] == 1 {
+						next = append(next, embedded{typ: ft, index: f.Index})
+					}
+					continue
+				}
+
+				name, skip, err := b.transformFieldName(f)
+				if err != nil {
+					return nil, errors.Wrapf(err, "transformFieldName")
+				}
+				if skip {
+					continue
+				}
+				if _, ok := levelFields[name]; !ok {
+					levelNames = append(levelNames, name)
+				}
+				sf := structField{field: f, name: name, tagged: b.hasJsonTagName(f)}
+				for n := 0; n < count[e.typ]; n++ {
+					levelFields[name] = append(levelFields[name], sf)
+				}
+			}
+		}
+
+		for _, name := range levelNames {
+			if containsStructField(fields, name) {
+				continue // Shadowed by a shallower field
+			}
+			if f, ok := dominantField(levelFields[name]); ok {
+				fields = append(fields, f)
+			} else {
+				// Mark the name as taken so that deeper fields do not appear in place of the conflicting ones.
+				fields = append(fields, structField{name: name})
+			}
+		}
+
+		current, next = next, nil
+		count, nextCount = nextCount, map[reflect.Type]int{}
+	}
+
+	result := make([]structField, 0, len(fields))
+	for _, f := range fields {
+		if f.field.Index != nil {
+			result = append(result, f)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return lessIndex(result[i].field.Index, result[j].field.Index)
+	})
+	return result, nil
+}
+
+// shouldFlatten tells whether the fields of an embedded struct field are promoted into the parent struct. Embedded
+// structs can be kept as a sub-object with the tag option "flatten:false", or when fields are named by their json
+// tags (see usesJsonTags) by giving them a name with a json tag.
+func (b *MappingPropertiesBuilder) shouldFlatten(field reflect.StructField) bool {
+	if !field.Anonymous {
+		return false
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	if reflect.PtrTo(t).Implements(reflect.TypeOf((*OpenSearchDateType)(nil)).Elem()) {
+		return false
+	}
+	if _, ok := getTypeProvider(t); ok {
+		return false
+	}
+	if parseFieldTags(field).flatten == "false" {
+		return false
+	}
+	if b.usesJsonTags() {
+		if tag := field.Tag.Get("json"); tag == "-" || b.hasJsonTagName(field) {
+			return false
+		}
+	}
+	return true
+}
+
+// usesJsonTags tells whether fields are named by their json tags, either with WithJsonTagFieldNames or with a
+// JsonTagFieldNameTransformer given to WithFieldNameTransformer.
+func (b *MappingPropertiesBuilder) usesJsonTags() bool {
+	switch b.optionContainer.fieldNameTransformer.(type) {
+	case *JsonTagFieldNameTransformer, JsonTagFieldNameTransformer:
+		return true
+	default:
+		return false
+	}
+}
+
+func (b *MappingPropertiesBuilder) hasJsonTagName(field reflect.StructField) bool {
+	if !b.usesJsonTags() {
+		return false
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return name != "" && name != "-"
+}
+
+// dominantField picks the field that wins among fields with the same name at the same depth.
+func dominantField(fields []structField) (structField, bool) {
+	if len(fields) == 1 {
+		return fields[0], true
+	}
+	var tagged []structField
+	for _, f := range fields {
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return structField{}, false
+}
+
+func containsStructField(fields []structField, name string) bool {
+	for _, f := range fields {
+		if f.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func lessIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
@@ -0,0 +1,436 @@
+package opensearchutil
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// StructGenerator generates Go struct definitions from mapping properties, e.g. those of a MappingParser, with `json`
+// and `opensearch` tags. Building mapping properties of the generated root struct with a MappingPropertiesBuilder
+// created with WithJsonTagFieldNames reproduces the mapping properties.
+type StructGenerator struct {
+	optionContainer structGeneratorOptionContainer
+}
+
+func NewStructGenerator(options ...StructGeneratorOption) *StructGenerator {
+	optContainer := structGeneratorOptionContainer{}
+	for _, o := range options {
+		o.apply(&optContainer)
+	}
+	return &StructGenerator{optionContainer: optContainer}
+}
+
+type (
+	generatedStruct struct {
+		name   string
+		fields []generatedField
+	}
+	generatedField struct {
+		name   string
+		goType string
+		tag    string
+	}
+
+	// structGeneration holds the state of a single GenerateStructs call.
+	structGeneration struct {
+		generator   *StructGenerator
+		structs     []*generatedStruct
+		typeNames   map[string]bool
+		usesThisPkg bool
+		fieldErrors FieldErrors
+	}
+)
+
+// thisPackageName qualifies the types of this package in generated code.
+const thisPackageName = "opensearchutil"
+
+// dateTypesByFormat are the types of this package that map to "date" fields of a format.
+var dateTypesByFormat = map[string]string{
+	"basic_date_time":           "TimeBasicDateTime",
+	"basic_date_time_no_millis": "TimeBasicDateTimeNoMillis",
+	"basic_date":                "TimeBasicDate",
+	"basic_ordinal_date":        "TimeBasicOrdinalDate",
+	"strict_date_optional_time": "TimeStrictDateOptionalTime",
+	"date_time":                 "TimeDateTime",
+	"date_time_no_millis":       "TimeDateTimeNoMillis",
+	"date":                      "TimeDate",
+	"date_hour_minute_second":   "TimeDateHourMinuteSecond",
+	"ordinal_date":              "TimeOrdinalDate",
+	"week_date":                 "TimeWeekDate",
+	"hour_minute_second":        "TimeHourMinuteSecond",
+	"year_month":                "TimeYearMonth",
+	"year":                      "TimeYear",
+	"epoch_millis":              "TimeEpochMillis",
+	"epoch_second":              "NumericTimeDate",
+}
+
+// goTypesByFieldType are the Go types of other OpenSearch field types. Field types not listed here are generated as
+// interface{} fields with a "type" tag option.
+var goTypesByFieldType = map[string]string{
+	"text":               "string",
+	"keyword":            "string",
+	"constant_keyword":   "string",
+	"wildcard":           "string",
+	"match_only_text":    "string",
+	"search_as_you_type": "string",
+	"ip":                 "string",
+	"version":            "string",
+	"date":               "string",
+	"date_nanos":         "string",
+	"binary":             "string",
+	"boolean":            "bool",
+	"long":               "int64",
+	"integer":            "int",
+	"short":              "int16",
+	"byte":               "int8",
+	"unsigned_long":      "uint64",
+	"double":             "float64",
+	"float":              "float32",
+	"half_float":         "float32",
+	"scaled_float":       "float64",
+}
+
+// defaultFieldTypesByGoType are the field types MappingPropertiesBuilder maps Go types to without a "type" tag option.
+var defaultFieldTypesByGoType = map[string]string{
+	"string":  "text",
+	"bool":    "boolean",
+	"int64":   "integer",
+	"int":     "integer",
+	"int16":   "integer",
+	"int8":    "integer",
+	"uint64":  "integer",
+	"float64": "float",
+	"float32": "float",
+}
+
+// commonInitialisms are the name parts that are upper-cased as a whole in Go field names, e.g. "user_id" -> "UserID".
+var commonInitialisms = map[string]bool{
+	"api": true, "html": true, "http": true, "id": true, "ip": true, "json": true, "sql": true, "uid": true,
+	"uri": true, "url": true, "uuid": true, "xml": true,
+}
+
+// GenerateStructs generates a formatted Go source file of package packageName, which defines a struct type typeName
+// for the mapping properties and a struct type for each object or nested property in it.
+//
+// Properties are mapped to Go types as follows: objects to structs, nested properties to slices of structs, "date"
+// properties with the format of a Time* type of this package or of NumericTimeDate to those types, other properties to
+// Go types of the same kind (e.g. "long" to int64, "keyword" to string), dynamic objects and "flat_object" properties
+// to maps. Attributes of properties that cannot be expressed with tags are reported as FieldErrors, unless the
+// IgnoreUnsupportedAttributes option is set.
+func (g *StructGenerator) GenerateStructs(
+	packageName string,
+	typeName string,
+	mappingProperties []MappingProperty,
+) ([]byte, error) {
+	if !token.IsIdentifier(packageName) {
+		return nil, fmt.Errorf("invalid package name %q", packageName)
+	}
+	if !token.IsIdentifier(typeName) || !token.IsExported(typeName) {
+		return nil, fmt.Errorf("invalid type name %q, expected an exported identifier", typeName)
+	}
+
+	gen := &structGeneration{
+		generator: g,
+		typeNames: make(map[string]bool),
+	}
+	gen.addStruct(typeName, mappingProperties, "")
+	if len(gen.fieldErrors) > 0 {
+		return nil, gen.fieldErrors
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by opensearchutil. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n", packageName)
+	if gen.usesThisPkg {
+		buf.WriteString("\nimport \"github.com/varfrog/opensearchutil\"\n")
+	}
+	for _, s := range gen.structs {
+		fmt.Fprintf(&buf, "\ntype %s struct {\n", s.name)
+		for _, f := range s.fields {
+			fmt.Fprintf(&buf, "%s %s `%s`\n", f.name, f.goType, f.tag)
+		}
+		buf.WriteString("}\n")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "format.Source")
+	}
+	return src, nil
+}
+
+// addStruct adds a struct type for the properties, and the struct types of its object properties after it. It
+// returns the name of the type, which is typeName made unique.
+func (s *structGeneration) addStruct(typeName string, mappingProperties []MappingProperty, pathPrefix string) string {
+	st := &generatedStruct{name: s.uniqueTypeName(typeName)}
+	s.structs = append(s.structs, st)
+
+	fieldNames := make(map[string]bool, len(mappingProperties))
+	for _, mp := range mappingProperties {
+		path := pathPrefix + mp.FieldName
+		fieldName := uniqueName(goFieldName(mp.FieldName), fieldNames)
+		fieldNames[fieldName] = true
+
+		goType, tagOptions := s.resolveGoField(mp, path, st.name+fieldName)
+		tag, err := buildStructTag(mp.FieldName, tagOptions)
+		if err != nil {
+			s.fail(path, err.Error())
+			continue
+		}
+		st.fields = append(st.fields, generatedField{name: fieldName, goType: goType, tag: tag})
+	}
+	return st.name
+}
+
+// resolveGoField returns the Go type and the tag options of the field of a property. typeName is the name of the
+// struct type generated for an object property.
+func (s *structGeneration) resolveGoField(mp MappingProperty, path string, typeName string) (string, []string) {
+	if mp.DynamicTemplate != nil || mp.FieldType == string(MapStrategyFlatObject) ||
+		(mp.FieldType == fieldTypeObject && mp.Children == nil && mp.Dynamic != nil && *mp.Dynamic == "true") {
+		return s.resolveMapField(mp, path)
+	}
+
+	if mp.Children != nil || isObjectFieldType(mp.FieldType) || mp.FieldType == "" {
+		var tagOptions []string
+		if mp.FieldType != "" {
+			tagOptions = append(tagOptions, tagOptionType+":"+mp.FieldType)
+		}
+		if mp.IncludeInParent != nil {
+			tagOptions = append(tagOptions, tagOptionIncludeInParent+":"+strconv.FormatBool(*mp.IncludeInParent))
+		}
+		if mp.IncludeInRoot != nil {
+			tagOptions = append(tagOptions, tagOptionIncludeInRoot+":"+strconv.FormatBool(*mp.IncludeInRoot))
+		}
+		if mp.Dynamic != nil {
+			s.unsupported(path, "dynamic on an object with properties")
+		}
+		s.checkLeafAttributes(mp, path)
+
+		typeName = s.addStruct(typeName, mp.Children, path+".")
+		if mp.FieldType == fieldTypeNested {
+			return "[]" + typeName, tagOptions
+		}
+		return typeName, tagOptions
+	}
+
+	if mp.Dynamic != nil {
+		s.unsupported(path, "dynamic on a field of type "+mp.FieldType)
+	}
+	return s.resolveLeafField(mp, path)
+}
+
+// resolveMapField returns the Go type and the tag options of a map field: a dynamic object, possibly with a dynamic
+// template for its values, or a "flat_object" field.
+func (s *structGeneration) resolveMapField(mp MappingProperty, path string) (string, []string) {
+	if mp.Children != nil {
+		s.unsupported(path, "a dynamic template on an object with properties")
+	}
+	s.checkLeafAttributes(mp, path)
+
+	if mp.FieldType == string(MapStrategyFlatObject) {
+		return "map[string]interface{}", []string{tagOptionMap + ":" + string(MapStrategyFlatObject)}
+	}
+	if mp.DynamicTemplate == nil {
+		return "map[string]interface{}", []string{tagOptionMap + ":" + string(MapStrategyObject)}
+	}
+	if mp.FieldType != fieldTypeObject || mp.Dynamic == nil || *mp.Dynamic != "true" {
+		s.unsupported(path, "a dynamic template on a property other than an object with dynamic true")
+	}
+
+	valueType, valueTagOptions := s.resolveLeafField(*mp.DynamicTemplate, path+".*")
+	return "map[string]" + valueType, append([]string{tagOptionMap + ":" + string(MapStrategyDynamicTemplate)},
+		valueTagOptions...)
+}
+
+// resolveLeafField returns the Go type and the tag options of a field of a property with a field type.
+func (s *structGeneration) resolveLeafField(mp MappingProperty, path string) (string, []string) {
+	var goType string
+	if mp.FieldType == "date" && mp.FieldFormat != nil && dateTypesByFormat[*mp.FieldFormat] != "" {
+		goType = thisPackageName + "." + dateTypesByFormat[*mp.FieldFormat]
+		s.usesThisPkg = true
+	} else if t, ok := goTypesByFieldType[mp.FieldType]; ok {
+		goType = t
+	} else {
+		goType = "interface{}"
+	}
+
+	var tagOptions []string
+	dateFormat, isDateType := dateTypeFormat(goType)
+	if !isDateType && defaultFieldTypesByGoType[goType] != mp.FieldType {
+		tagOptions = append(tagOptions, tagOptionType+":"+mp.FieldType)
+	}
+	if mp.FieldFormat != nil && (!isDateType || dateFormat != *mp.FieldFormat) {
+		tagOptions = append(tagOptions, tagOptionFormat+":"+*mp.FieldFormat)
+	}
+	if mp.Analyzer != nil {
+		tagOptions = append(tagOptions, tagOptionAnalyzer+":"+*mp.Analyzer)
+	}
+	if mp.SearchAnalyzer != nil {
+		tagOptions = append(tagOptions, tagOptionSearchAnalyzer+":"+*mp.SearchAnalyzer)
+	}
+	if mp.Normalizer != nil {
+		tagOptions = append(tagOptions, tagOptionNormalizer+":"+*mp.Normalizer)
+	}
+	if mp.IgnoreAbove != nil {
+		tagOptions = append(tagOptions, tagOptionIgnoreAbove+":"+strconv.FormatUint(uint64(*mp.IgnoreAbove), 10))
+	}
+	if mp.ScalingFactor != nil {
+		tagOptions = append(tagOptions,
+			tagOptionScalingFactor+":"+strconv.FormatFloat(*mp.ScalingFactor, 'f', -1, 64))
+	}
+	if len(mp.CopyTo) > 0 {
+		tagOptions = append(tagOptions, tagOptionCopyTo+":"+strings.Join(mp.CopyTo, ";"))
+	}
+	if mp.IndexPrefixes != nil {
+		tagOptions = append(tagOptions, tagOptionIndexPrefixes+":"+formatCustomPropertyValue(*mp.IndexPrefixes))
+	}
+	if mp.IncludeInParent != nil || mp.IncludeInRoot != nil {
+		s.unsupported(path, "include_in_parent or include_in_root on a field of type "+mp.FieldType)
+	}
+	for _, subField := range mp.Fields {
+		tagOptions = append(tagOptions, tagOptionFieldsPrefix+subField.FieldName+":"+
+			s.formatSubField(subField, path+"."+subField.FieldName))
+	}
+	return goType, tagOptions
+}
+
+// formatSubField formats a multi-field as the value of a "fields." tag option, e.g. "type=keyword;ignore_above=256".
+func (s *structGeneration) formatSubField(subField MappingProperty, path string) string {
+	if subField.FieldFormat != nil || subField.ScalingFactor != nil || len(subField.CopyTo) > 0 ||
+		subField.IndexPrefixes != nil || subField.IncludeInParent != nil || subField.IncludeInRoot != nil ||
+		subField.Dynamic != nil || subField.DynamicTemplate != nil || subField.Fields != nil || subField.Children != nil {
+		s.unsupported(path, "multi-field attributes other than type, analyzer, search_analyzer, normalizer and "+
+			"ignore_above")
+	}
+
+	opts := make(map[string]string)
+	if subField.FieldType != fieldTypeKeyword {
+		opts[tagOptionType] = subField.FieldType
+	}
+	if subField.Analyzer != nil {
+		opts[tagOptionAnalyzer] = *subField.Analyzer
+	}
+	if subField.SearchAnalyzer != nil {
+		opts[tagOptionSearchAnalyzer] = *subField.SearchAnalyzer
+	}
+	if subField.Normalizer != nil {
+		opts[tagOptionNormalizer] = *subField.Normalizer
+	}
+	if subField.IgnoreAbove != nil {
+		opts[tagOptionIgnoreAbove] = strconv.FormatUint(uint64(*subField.IgnoreAbove), 10)
+	}
+	return formatCustomPropertyValue(opts)
+}
+
+// checkLeafAttributes reports the attributes of fields with a field type that are set on an object or a map.
+func (s *structGeneration) checkLeafAttributes(mp MappingProperty, path string) {
+	if mp.FieldFormat != nil || mp.Analyzer != nil || mp.SearchAnalyzer != nil || mp.Normalizer != nil ||
+		mp.IgnoreAbove != nil || mp.ScalingFactor != nil || len(mp.CopyTo) > 0 || mp.IndexPrefixes != nil ||
+		mp.Fields != nil {
+		s.unsupported(path, "attributes of fields with a field type on an object")
+	}
+}
+
+// dateTypeFormat returns the date format of a date type of this package.
+func dateTypeFormat(goType string) (string, bool) {
+	for format, t := range dateTypesByFormat {
+		if thisPackageName+"."+t == goType {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+func (s *structGeneration) unsupported(path string, what string) {
+	if s.generator.optionContainer.ignoreUnsupportedAttributes {
+		return
+	}
+	s.fail(path, "cannot express "+what+" with tags")
+}
+
+func (s *structGeneration) fail(path string, reason string) {
+	s.fieldErrors = append(s.fieldErrors, &FieldError{Path: path, Reason: reason})
+}
+
+func (s *structGeneration) uniqueTypeName(name string) string {
+	name = uniqueName(name, s.typeNames)
+	s.typeNames[name] = true
+	return name
+}
+
+// buildStructTag builds the tag of a field from the property name and the "opensearch" tag options.
+func buildStructTag(propertyName string, tagOptions []string) (string, error) {
+	if propertyName == "" || propertyName == "-" || strings.ContainsAny(propertyName, ",\"`\\") {
+		return "", fmt.Errorf("property name %q cannot be used in a json tag", propertyName)
+	}
+	tag := `json:"` + propertyName + `"`
+	if len(tagOptions) == 0 {
+		return tag, nil
+	}
+
+	for _, opt := range tagOptions {
+		if strings.ContainsAny(opt, ",`") {
+			return "", fmt.Errorf("tag option %q contains a comma or a backtick", opt)
+		}
+	}
+	return tag + " " + tagKey + ":" + strconv.Quote(strings.Join(tagOptions, ",")), nil
+}
+
+// formatCustomPropertyValue formats a map as "key1=val1;key2=val2", sorted by keys. It is the reverse of
+// parseCustomPropertyValue.
+func formatCustomPropertyValue(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+m[k])
+	}
+	return strings.Join(pairs, ";")
+}
+
+// goFieldName converts a property name like "created_at" or "@timestamp" into an exported Go field name like
+// "CreatedAt" or "Timestamp".
+func goFieldName(propertyName string) string {
+	parts := strings.FieldsFunc(propertyName, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, part := range parts {
+		if commonInitialisms[strings.ToLower(part)] {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		runes := []rune(part)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+
+	name := b.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) || !token.IsExported(name) {
+		name = "Field" + name
+	}
+	return name
+}
+
+// uniqueName appends a number to name if it is already used.
+func uniqueName(name string, used map[string]bool) string {
+	if !used[name] {
+		return name
+	}
+	for i := 2; ; i++ {
+		if candidate := name + strconv.Itoa(i); !used[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package opensearchutil
+
+type StructGeneratorOption interface {
+	apply(*structGeneratorOptionContainer)
+}
+
+type structGeneratorOptionContainer struct {
+	ignoreUnsupportedAttributes bool
+}
+
+type ignoreUnsupportedAttributesOption struct{}
+
+func (c ignoreUnsupportedAttributesOption) apply(opts *structGeneratorOptionContainer) {
+	opts.ignoreUnsupportedAttributes = true
+}
+
+// IgnoreUnsupportedAttributes makes StructGenerator leave out attributes of properties that cannot be expressed with
+// tags, such as "dynamic" on objects with properties, instead of failing. The generated structs then reproduce the
+// mapping without these attributes.
+//
+//goland:noinspection GoUnusedExportedFunction
+func IgnoreUnsupportedAttributes() StructGeneratorOption {
+	return ignoreUnsupportedAttributesOption{}
+}
@@ -0,0 +1,244 @@
+package opensearchutil
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestStructGenerator_GenerateStructs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	index, err := NewMappingParser().ParseIndexJson([]byte(structGeneratorTestMapping))
+	g.Expect(err).To(gomega.BeNil())
+
+	src, err := NewStructGenerator().GenerateStructs("models", "Product", index.MappingProperties)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(src)).To(gomega.Equal(`// Code generated by opensearchutil. DO NOT EDIT.
+
+package models
+
+import "github.com/varfrog/opensearchutil"
+
+type Product struct {
+	Timestamp    opensearchutil.TimeBasicDateTime ` + "`json:\"@timestamp\"`" + `
+	Active       bool                             ` + "`json:\"active\"`" + `
+	AllText      string                           ` + "`json:\"all_text\"`" + `
+	Attributes   map[string]interface{}           ` + "`json:\"attributes\" opensearch:\"map:flat_object\"`" + `
+	Description  string                           ` + "`json:\"description\" opensearch:\"index_prefixes:max_chars=10;min_chars=2\"`" + `
+	Discount     float64                          ` + "`json:\"discount\" opensearch:\"type:scaled_float,scaling_factor:100\"`" + `
+	Extra        map[string]interface{}           ` + "`json:\"extra\" opensearch:\"map:object\"`" + `
+	Labels       map[string]string                ` + "`json:\"labels\" opensearch:\"map:dynamic_template,type:keyword,ignore_above:64\"`" + `
+	Location     interface{}                      ` + "`json:\"location\" opensearch:\"type:geo_point\"`" + `
+	Manufacturer ProductManufacturer              ` + "`json:\"manufacturer\"`" + `
+	Name         string                           ` + "`json:\"name\" opensearch:\"analyzer:standard,copy_to:all_text,fields.en:analyzer=english;type=text,fields.raw:ignore_above=256\"`" + `
+	Price        float64                          ` + "`json:\"price\" opensearch:\"type:double\"`" + `
+	ProductID    string                           ` + "`json:\"product_id\" opensearch:\"type:keyword\"`" + `
+	PublishedAt  string                           ` + "`json:\"published_at\" opensearch:\"type:date,format:strict_date_optional_time||epoch_millis\"`" + `
+	Rating       float32                          ` + "`json:\"rating\"`" + `
+	ReleasedOn   opensearchutil.TimeBasicDate     ` + "`json:\"released_on\"`" + `
+	Stock        int                              ` + "`json:\"stock\"`" + `
+	UpdatedAt    opensearchutil.NumericTimeDate   ` + "`json:\"updated_at\"`" + `
+	Variants     []ProductVariants                ` + "`json:\"variants\" opensearch:\"type:nested,include_in_parent:true\"`" + `
+	Views        int64                            ` + "`json:\"views\" opensearch:\"type:long\"`" + `
+}
+
+type ProductManufacturer struct {
+	Address ProductManufacturerAddress ` + "`json:\"address\"`" + `
+	Name    string                     ` + "`json:\"name\" opensearch:\"type:keyword,normalizer:lowercase\"`" + `
+}
+
+type ProductManufacturerAddress struct {
+	City string ` + "`json:\"city\" opensearch:\"type:keyword\"`" + `
+}
+
+type ProductVariants struct {
+	Size int16  ` + "`json:\"size\" opensearch:\"type:short\"`" + `
+	Sku  string ` + "`json:\"sku\" opensearch:\"type:keyword\"`" + `
+}
+`))
+}
+
+// The structs generated from structGeneratorTestMapping, in this package.
+type (
+	structGeneratorTestProduct struct {
+		Timestamp    TimeBasicDateTime                      `json:"@timestamp"`
+		Active       bool                                   `json:"active"`
+		AllText      string                                 `json:"all_text"`
+		Attributes   map[string]interface{}                 `json:"attributes" opensearch:"map:flat_object"`
+		Description  string                                 `json:"description" opensearch:"index_prefixes:max_chars=10;min_chars=2"`
+		Discount     float64                                `json:"discount" opensearch:"type:scaled_float,scaling_factor:100"`
+		Extra        map[string]interface{}                 `json:"extra" opensearch:"map:object"`
+		Labels       map[string]string                      `json:"labels" opensearch:"map:dynamic_template,type:keyword,ignore_above:64"`
+		Location     interface{}                            `json:"location" opensearch:"type:geo_point"`
+		Manufacturer structGeneratorTestProductManufacturer `json:"manufacturer"`
+		Name         string                                 `json:"name" opensearch:"analyzer:standard,copy_to:all_text,fields.en:analyzer=english;type=text,fields.raw:ignore_above=256"`
+		Price        float64                                `json:"price" opensearch:"type:double"`
+		ProductID    string                                 `json:"product_id" opensearch:"type:keyword"`
+		PublishedAt  string                                 `json:"published_at" opensearch:"type:date,format:strict_date_optional_time||epoch_millis"`
+		Rating       float32                                `json:"rating"`
+		ReleasedOn   TimeBasicDate                          `json:"released_on"`
+		Stock        int                                    `json:"stock"`
+		UpdatedAt    NumericTimeDate                        `json:"updated_at"`
+		Variants     []structGeneratorTestProductVariants   `json:"variants" opensearch:"type:nested,include_in_parent:true"`
+		Views        int64                                  `json:"views" opensearch:"type:long"`
+	}
+	structGeneratorTestProductManufacturer struct {
+		Address structGeneratorTestProductManufacturerAddress `json:"address"`
+		Name    string                                        `json:"name" opensearch:"type:keyword,normalizer:lowercase"`
+	}
+	structGeneratorTestProductManufacturerAddress struct {
+		City string `json:"city" opensearch:"type:keyword"`
+	}
+	structGeneratorTestProductVariants struct {
+		Size int16  `json:"size" opensearch:"type:short"`
+		Sku  string `json:"sku" opensearch:"type:keyword"`
+	}
+)
+
+func TestStructGenerator_GenerateStructs_RoundTrip(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	index, err := NewMappingParser().ParseIndexJson([]byte(structGeneratorTestMapping))
+	g.Expect(err).To(gomega.BeNil())
+
+	mps, err := NewMappingPropertiesBuilder(WithJsonTagFieldNames()).
+		BuildMappingProperties(structGeneratorTestProduct{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(mps).To(gomega.Equal(index.MappingProperties))
+}
+
+func TestStructGenerator_GenerateStructs_UnsupportedAttributes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mps := []MappingProperty{
+		{
+			FieldName: "meta",
+			Dynamic:   MakePtr("strict"),
+			Children:  []MappingProperty{{FieldName: "source", FieldType: "keyword"}},
+		},
+		{
+			FieldName: "title",
+			FieldType: "text",
+			Fields:    []MappingProperty{{FieldName: "date", FieldType: "date", FieldFormat: MakePtr("yyyy")}},
+		},
+		{FieldName: "a,b", FieldType: "keyword"},
+	}
+
+	_, err := NewStructGenerator().GenerateStructs("models", "Doc", mps)
+	g.Expect(err).To(gomega.MatchError(FieldErrors{
+		{Path: "meta", Reason: "cannot express dynamic on an object with properties with tags"},
+		{
+			Path: "title.date",
+			Reason: "cannot express multi-field attributes other than type, analyzer, search_analyzer, normalizer " +
+				"and ignore_above with tags",
+		},
+		{Path: "a,b", Reason: `property name "a,b" cannot be used in a json tag`},
+	}))
+
+	src, err := NewStructGenerator(IgnoreUnsupportedAttributes()).GenerateStructs("models", "Doc", mps[:1])
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(src)).To(gomega.Equal(`// Code generated by opensearchutil. DO NOT EDIT.
+
+package models
+
+type Doc struct {
+	Meta DocMeta ` + "`json:\"meta\"`" + `
+}
+
+type DocMeta struct {
+	Source string ` + "`json:\"source\" opensearch:\"type:keyword\"`" + `
+}
+`))
+}
+
+func TestStructGenerator_GenerateStructs_DateFormats(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	mps := []MappingProperty{
+		{FieldName: "day", FieldType: "date", FieldFormat: MakePtr("date")},
+		{FieldName: "week", FieldType: "date", FieldFormat: MakePtr("week_date")},
+		{FieldName: "created_at", FieldType: "date", FieldFormat: MakePtr("epoch_millis")},
+	}
+
+	src, err := NewStructGenerator().GenerateStructs("models", "Doc", mps)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(string(src)).To(gomega.ContainSubstring("Day       opensearchutil.TimeDate        `json:\"day\"`"))
+	g.Expect(string(src)).To(gomega.ContainSubstring("Week      opensearchutil.TimeWeekDate    `json:\"week\"`"))
+	g.Expect(string(src)).To(gomega.ContainSubstring(
+		"CreatedAt opensearchutil.TimeEpochMillis `json:\"created_at\"`"))
+}
+
+func TestStructGenerator_GenerateStructs_InvalidNames(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	_, err := NewStructGenerator().GenerateStructs("my-models", "Doc", nil)
+	g.Expect(err).To(gomega.MatchError(`invalid package name "my-models"`))
+
+	_, err = NewStructGenerator().GenerateStructs("models", "doc", nil)
+	g.Expect(err).To(gomega.MatchError(`invalid type name "doc", expected an exported identifier`))
+}
+
+func Test_goFieldName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	for propertyName, expected := range map[string]string{
+		"created_at":   "CreatedAt",
+		"@timestamp":   "Timestamp",
+		"user_id":      "UserID",
+		"source.url":   "SourceURL",
+		"camelCase":    "CamelCase",
+		"3d_model":     "Field3dModel",
+		"_":            "Field",
+		"http-headers": "HTTPHeaders",
+	} {
+		g.Expect(goFieldName(propertyName)).To(gomega.Equal(expected), propertyName)
+	}
+}
+
+const structGeneratorTestMapping = `{
+  "mappings": {
+    "dynamic_templates": [
+      {"labels": {"path_match": "labels.*", "mapping": {"type": "keyword", "ignore_above": 64}}}
+    ],
+    "properties": {
+      "@timestamp": {"type": "date", "format": "basic_date_time"},
+      "product_id": {"type": "keyword"},
+      "name": {
+        "type": "text",
+        "analyzer": "standard",
+        "copy_to": ["all_text"],
+        "fields": {"raw": {"type": "keyword", "ignore_above": 256}, "en": {"type": "text", "analyzer": "english"}}
+      },
+      "all_text": {"type": "text"},
+      "description": {"type": "text", "index_prefixes": {"min_chars": 2, "max_chars": 10}},
+      "price": {"type": "double"},
+      "discount": {"type": "scaled_float", "scaling_factor": 100},
+      "stock": {"type": "integer"},
+      "views": {"type": "long"},
+      "rating": {"type": "float"},
+      "active": {"type": "boolean"},
+      "released_on": {"type": "date", "format": "basic_date"},
+      "updated_at": {"type": "date", "format": "epoch_second"},
+      "published_at": {"type": "date", "format": "strict_date_optional_time||epoch_millis"},
+      "location": {"type": "geo_point"},
+      "attributes": {"type": "flat_object"},
+      "extra": {"type": "object", "dynamic": "true"},
+      "labels": {"type": "object", "dynamic": "true"},
+      "manufacturer": {
+        "properties": {
+          "name": {"type": "keyword", "normalizer": "lowercase"},
+          "address": {"properties": {"city": {"type": "keyword"}}}
+        }
+      },
+      "variants": {
+        "type": "nested",
+        "include_in_parent": true,
+        "properties": {
+          "sku": {"type": "keyword"},
+          "size": {"type": "short"}
+        }
+      }
+    }
+  }
+}`
@@ -23,6 +23,45 @@ type (
 	// TimeBasicDate marshalls into OpenSearch basic_date type
 	TimeBasicDate time.Time
 
+	// TimeBasicOrdinalDate marshalls into OpenSearch basic_ordinal_date type
+	TimeBasicOrdinalDate time.Time
+
+	// TimeStrictDateOptionalTime marshalls into OpenSearch strict_date_optional_time type
+	TimeStrictDateOptionalTime time.Time
+
+	// TimeDateTime marshalls into OpenSearch date_time type
+	TimeDateTime time.Time
+
+	// TimeDateTimeNoMillis marshalls into OpenSearch date_time_no_millis type
+	TimeDateTimeNoMillis time.Time
+
+	// TimeDate marshalls into OpenSearch date type
+	TimeDate time.Time
+
+	// TimeDateHourMinuteSecond marshalls into OpenSearch date_hour_minute_second type
+	TimeDateHourMinuteSecond time.Time
+
+	// TimeOrdinalDate marshalls into OpenSearch ordinal_date type
+	TimeOrdinalDate time.Time
+
+	// TimeWeekDate marshalls into OpenSearch week_date type
+	TimeWeekDate time.Time
+
+	// TimeHourMinuteSecond marshalls into OpenSearch hour_minute_second type
+	TimeHourMinuteSecond time.Time
+
+	// TimeYearMonth marshalls into OpenSearch year_month type
+	TimeYearMonth time.Time
+
+	// TimeYear marshalls into OpenSearch year type
+	TimeYear time.Time
+
+	// TimeEpochMillis marshalls into OpenSearch epoch_millis type
+	TimeEpochMillis time.Time
+
+	// TimeEpochSecond marshalls into OpenSearch epoch_second type
+	TimeEpochSecond time.Time
+
 	// NumericTime marshals to and from Unix timestamps (integer "long" values) to make sorting on dates possible.
 	//
 	// OpenSearch supports "date" fields for indexing and querying date-time values. However, using "date" fields for sorting
@@ -40,6 +79,10 @@ type (
 	// This type is designed to work alongside "date" fields when necessary. For example, you can use the "date" field for
 	// full-text queries and range filters, while relying on NumericTime for efficient sorting operations.
 	NumericTime struct{ time.Time }
+
+	// NumericTimeDate marshals the same as NumericTime, but is mapped as a "date" field with the "epoch_second" format
+	// instead of a "long" field, for when the field is also used in date range queries and date aggregations.
+	NumericTimeDate struct{ NumericTime }
 )
 
 // OpenSearchDateType tells MappingPropertiesBuilder that a type is a "date" OpenSearch type.
@@ -47,19 +90,64 @@ type OpenSearchDateType interface {
 	GetOpenSearchDateFieldType() string
 }
 
+// marshalDateText formats a time with a registered date format, for the MarshalText methods of the Time* types.
+func marshalDateText(t time.Time, format string) ([]byte, error) {
+	text, err := FormatDate(t, format)
+	if err != nil {
+		return nil, errors.Wrap(err, "FormatDate")
+	}
+	return []byte(text), nil
+}
+
+// unmarshalDateText parses a value of a registered date format into t, for the UnmarshalText methods of the Time*
+// types.
+func unmarshalDateText(t *time.Time, text []byte, format string) error {
+	parsedTime, err := ParseDate(string(text), format)
+	if err != nil {
+		return errors.Wrap(err, "ParseDate")
+	}
+	*t = parsedTime
+	return nil
+}
+
+// unmarshalEpochJSON parses an epoch date given as a JSON number or string into t, leaving t as is for null.
+func unmarshalEpochJSON(t *time.Time, data []byte, format string) error {
+	if string(data) == "null" {
+		return nil
+	}
+	text, err := dateTextFromJSON(data)
+	if err != nil {
+		return errors.Wrap(err, "dateTextFromJSON")
+	}
+	return unmarshalDateText(t, text, format)
+}
+
+// dateTextFromJSON returns the text of a date given as a JSON string or number.
+func dateTextFromJSON(data []byte) ([]byte, error) {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, errors.Wrap(err, "json.Unmarshal")
+		}
+		return []byte(s), nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+	return []byte(number), nil
+}
+
+// TimeBasicDateTime
+
 //goland:noinspection GoMixedReceiverTypes
 func (t TimeBasicDateTime) MarshalText() ([]byte, error) {
-	return []byte(time.Time(t).Format(FormatTimeBasicDateTime)), nil
+	return marshalDateText(time.Time(t), "basic_date_time")
 }
 
 //goland:noinspection GoMixedReceiverTypes
 func (t *TimeBasicDateTime) UnmarshalText(text []byte) error {
-	parsedTime, err := time.Parse(FormatTimeBasicDateTime, string(text))
-	if err != nil {
-		return errors.Wrap(err, "time.Parse")
-	}
-	*t = TimeBasicDateTime(parsedTime)
-	return nil
+	return unmarshalDateText((*time.Time)(t), text, "basic_date_time")
 }
 
 //goland:noinspection GoMixedReceiverTypes
@@ -71,17 +159,12 @@ func (t TimeBasicDateTime) GetOpenSearchDateFieldType() string {
 
 //goland:noinspection GoMixedReceiverTypes
 func (t TimeBasicDateTimeNoMillis) MarshalText() ([]byte, error) {
-	return []byte(time.Time(t).Format(FormatTimeBasicDateTimeNoMillis)), nil
+	return marshalDateText(time.Time(t), "basic_date_time_no_millis")
 }
 
 //goland:noinspection GoMixedReceiverTypes
 func (t *TimeBasicDateTimeNoMillis) UnmarshalText(text []byte) error {
-	parsedTime, err := time.Parse(FormatTimeBasicDateTimeNoMillis, string(text))
-	if err != nil {
-		return errors.Wrap(err, "time.Parse")
-	}
-	*t = TimeBasicDateTimeNoMillis(parsedTime)
-	return nil
+	return unmarshalDateText((*time.Time)(t), text, "basic_date_time_no_millis")
 }
 
 //goland:noinspection GoMixedReceiverTypes
@@ -93,17 +176,12 @@ func (t TimeBasicDateTimeNoMillis) GetOpenSearchDateFieldType() string {
 
 //goland:noinspection GoMixedReceiverTypes
 func (t TimeBasicDate) MarshalText() ([]byte, error) {
-	return []byte(time.Time(t).Format(FormatTimeBasicDate)), nil
+	return marshalDateText(time.Time(t), "basic_date")
 }
 
 //goland:noinspection GoMixedReceiverTypes
 func (t *TimeBasicDate) UnmarshalText(text []byte) error {
-	parsedTime, err := time.Parse(FormatTimeBasicDate, string(text))
-	if err != nil {
-		return errors.Wrap(err, "time.Parse")
-	}
-	*t = TimeBasicDate(parsedTime)
-	return nil
+	return unmarshalDateText((*time.Time)(t), text, "basic_date")
 }
 
 //goland:noinspection GoMixedReceiverTypes
@@ -111,6 +189,255 @@ func (t TimeBasicDate) GetOpenSearchDateFieldType() string {
 	return "basic_date"
 }
 
+// TimeBasicOrdinalDate
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeBasicOrdinalDate) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "basic_ordinal_date")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeBasicOrdinalDate) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "basic_ordinal_date")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeBasicOrdinalDate) GetOpenSearchDateFieldType() string {
+	return "basic_ordinal_date"
+}
+
+// TimeStrictDateOptionalTime
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeStrictDateOptionalTime) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "strict_date_optional_time")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeStrictDateOptionalTime) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "strict_date_optional_time")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeStrictDateOptionalTime) GetOpenSearchDateFieldType() string {
+	return "strict_date_optional_time"
+}
+
+// TimeDateTime
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeDateTime) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "date_time")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeDateTime) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "date_time")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeDateTime) GetOpenSearchDateFieldType() string {
+	return "date_time"
+}
+
+// TimeDateTimeNoMillis
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeDateTimeNoMillis) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "date_time_no_millis")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeDateTimeNoMillis) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "date_time_no_millis")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeDateTimeNoMillis) GetOpenSearchDateFieldType() string {
+	return "date_time_no_millis"
+}
+
+// TimeDate
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeDate) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "date")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeDate) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "date")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeDate) GetOpenSearchDateFieldType() string {
+	return "date"
+}
+
+// TimeDateHourMinuteSecond
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeDateHourMinuteSecond) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "date_hour_minute_second")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeDateHourMinuteSecond) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "date_hour_minute_second")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeDateHourMinuteSecond) GetOpenSearchDateFieldType() string {
+	return "date_hour_minute_second"
+}
+
+// TimeOrdinalDate
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeOrdinalDate) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "ordinal_date")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeOrdinalDate) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "ordinal_date")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeOrdinalDate) GetOpenSearchDateFieldType() string {
+	return "ordinal_date"
+}
+
+// TimeWeekDate
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeWeekDate) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "week_date")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeWeekDate) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "week_date")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeWeekDate) GetOpenSearchDateFieldType() string {
+	return "week_date"
+}
+
+// TimeHourMinuteSecond
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeHourMinuteSecond) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "hour_minute_second")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeHourMinuteSecond) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "hour_minute_second")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeHourMinuteSecond) GetOpenSearchDateFieldType() string {
+	return "hour_minute_second"
+}
+
+// TimeYearMonth
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeYearMonth) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "year_month")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeYearMonth) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "year_month")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeYearMonth) GetOpenSearchDateFieldType() string {
+	return "year_month"
+}
+
+// TimeYear
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeYear) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "year")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeYear) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "year")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeYear) GetOpenSearchDateFieldType() string {
+	return "year"
+}
+
+// TimeEpochMillis
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeEpochMillis) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "epoch_millis")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeEpochMillis) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "epoch_millis")
+}
+
+// MarshalJSON marshals TimeEpochMillis into a JSON number.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeEpochMillis) MarshalJSON() ([]byte, error) {
+	return marshalDateText(time.Time(t), "epoch_millis")
+}
+
+// UnmarshalJSON unmarshals TimeEpochMillis from a JSON number or string.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeEpochMillis) UnmarshalJSON(data []byte) error {
+	return unmarshalEpochJSON((*time.Time)(t), data, "epoch_millis")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeEpochMillis) GetOpenSearchDateFieldType() string {
+	return "epoch_millis"
+}
+
+// TimeEpochSecond
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeEpochSecond) MarshalText() ([]byte, error) {
+	return marshalDateText(time.Time(t), "epoch_second")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeEpochSecond) UnmarshalText(text []byte) error {
+	return unmarshalDateText((*time.Time)(t), text, "epoch_second")
+}
+
+// MarshalJSON marshals TimeEpochSecond into a JSON number.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeEpochSecond) MarshalJSON() ([]byte, error) {
+	return marshalDateText(time.Time(t), "epoch_second")
+}
+
+// UnmarshalJSON unmarshals TimeEpochSecond from a JSON number or string.
+//
+//goland:noinspection GoMixedReceiverTypes
+func (t *TimeEpochSecond) UnmarshalJSON(data []byte) error {
+	return unmarshalEpochJSON((*time.Time)(t), data, "epoch_second")
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (t TimeEpochSecond) GetOpenSearchDateFieldType() string {
+	return "epoch_second"
+}
+
 // NumericTime
 
 // MarshalJSON converts NumericTime to a Unix timestamp (seconds) for JSON encoding.
@@ -137,3 +464,28 @@ func (nt NumericTime) Unix() int64 {
 func NewTimeNumericTime(t time.Time) NumericTime {
 	return NumericTime{t}
 }
+
+// GetOpenSearchFieldType makes MappingPropertiesBuilder map NumericTime as a "long" field.
+func (nt NumericTime) GetOpenSearchFieldType() string {
+	return "long"
+}
+
+func (nt NumericTime) GetOpenSearchFieldFormat() string {
+	return ""
+}
+
+// NumericTimeDate
+
+// NewNumericTimeDate is a constructor for NumericTimeDate.
+func NewNumericTimeDate(t time.Time) NumericTimeDate {
+	return NumericTimeDate{NumericTime{t}}
+}
+
+// GetOpenSearchFieldType makes MappingPropertiesBuilder map NumericTimeDate as a "date" field.
+func (nt NumericTimeDate) GetOpenSearchFieldType() string {
+	return "date"
+}
+
+func (nt NumericTimeDate) GetOpenSearchFieldFormat() string {
+	return "epoch_second"
+}
@@ -129,3 +129,92 @@ func TestNumericTime_JSONMarshallingAndUnmarshalling(t *testing.T) {
 	g.Expect(err).To(BeNil())
 	g.Expect(unmarshalledObj.Timestamp.Time.Equal(originalTime)).To(BeTrue())
 }
+
+func TestNumericTimeDate_JSONMarshallingAndUnmarshalling(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	originalTime := time.Date(2023, 1, 7, 15, 0, 0, 0, time.UTC)
+	res, err := json.Marshal(NewNumericTimeDate(originalTime))
+	g.Expect(err).To(BeNil())
+	g.Expect(string(res)).To(Equal("1673103600"))
+
+	var parsed NumericTimeDate
+	g.Expect(json.Unmarshal(res, &parsed)).To(Succeed())
+	g.Expect(parsed.Time.Equal(originalTime)).To(BeTrue())
+}
+
+func TestTimeFormats_JSONMarshallingAndUnmarshalling(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type foo struct {
+		OptionalTime TimeStrictDateOptionalTime `json:"optional_time"`
+		DateTime     TimeDateTime               `json:"date_time"`
+		Date         TimeDate                   `json:"date"`
+		OrdinalDate  TimeBasicOrdinalDate       `json:"ordinal_date"`
+		WeekDate     TimeWeekDate               `json:"week_date"`
+		Clock        TimeHourMinuteSecond       `json:"clock"`
+		Millis       TimeEpochMillis            `json:"millis"`
+		Seconds      *TimeEpochSecond           `json:"seconds"`
+	}
+
+	someTime := time.Date(2024, 1, 31, 21, 34, 46, 567000000, time.UTC)
+	jsonBytes, err := json.Marshal(foo{
+		OptionalTime: TimeStrictDateOptionalTime(someTime),
+		DateTime:     TimeDateTime(someTime),
+		Date:         TimeDate(someTime),
+		OrdinalDate:  TimeBasicOrdinalDate(someTime),
+		WeekDate:     TimeWeekDate(someTime),
+		Clock:        TimeHourMinuteSecond(someTime),
+		Millis:       TimeEpochMillis(someTime),
+	})
+	g.Expect(err).To(BeNil())
+	g.Expect(string(jsonBytes)).To(MatchJSON(`{
+		"optional_time": "2024-01-31T21:34:46.567Z",
+		"date_time": "2024-01-31T21:34:46.567Z",
+		"date": "2024-01-31",
+		"ordinal_date": "2024031",
+		"week_date": "2024-W05-3",
+		"clock": "21:34:46",
+		"millis": 1706736886567,
+		"seconds": null
+	}`))
+
+	var unmarshalled foo
+	g.Expect(json.Unmarshal(jsonBytes, &unmarshalled)).To(Succeed())
+	g.Expect(time.Time(unmarshalled.DateTime).Equal(someTime)).To(BeTrue())
+	g.Expect(time.Time(unmarshalled.WeekDate).Equal(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))).To(BeTrue())
+	g.Expect(time.Time(unmarshalled.Clock).Equal(time.Date(1970, 1, 1, 21, 34, 46, 0, time.UTC))).To(BeTrue())
+	g.Expect(time.Time(unmarshalled.Millis).Equal(someTime)).To(BeTrue())
+	g.Expect(unmarshalled.Seconds).To(BeNil())
+
+	// Epoch types also take strings, which OpenSearch returns for epoch values indexed as strings
+	var seconds TimeEpochSecond
+	g.Expect(json.Unmarshal([]byte(`"1706736886"`), &seconds)).To(Succeed())
+	g.Expect(time.Time(seconds).Equal(someTime.Truncate(time.Second))).To(BeTrue())
+	g.Expect(json.Unmarshal([]byte(`"1706736886\u0030"`), &seconds)).To(Succeed())
+	g.Expect(time.Time(seconds).Equal(time.Unix(17067368860, 0))).To(BeTrue())
+	for _, value := range []string{`"1706736886`, `1706736886"`, `true`} {
+		g.Expect(json.Unmarshal([]byte(`{"seconds": `+value+`}`), &unmarshalled)).NotTo(Succeed(), value)
+		g.Expect(seconds.UnmarshalJSON([]byte(value))).NotTo(Succeed(), value)
+	}
+
+	g.Expect(json.Unmarshal([]byte(`{"date": "31/01/2024"}`), &unmarshalled)).NotTo(Succeed())
+}
+
+func TestTimeFormats_MappingProperties(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type foo struct {
+		Date     TimeDate
+		WeekDate *TimeWeekDate
+		Millis   []TimeEpochMillis
+	}
+
+	mps, err := NewMappingPropertiesBuilder().BuildMappingProperties(foo{})
+	g.Expect(err).To(BeNil())
+	g.Expect(mps).To(Equal([]MappingProperty{
+		{FieldName: "date", FieldType: "date", FieldFormat: MakePtr("date")},
+		{FieldName: "week_date", FieldType: "date", FieldFormat: MakePtr("week_date")},
+		{FieldName: "millis", FieldType: "date", FieldFormat: MakePtr("epoch_millis")},
+	}))
+}
@@ -9,53 +9,60 @@ func MakePtr[V any](v V) *V {
 	return &v
 }
 
+// tagOption is a single key:value option of a struct tag.
+type tagOption struct {
+	key string
+	val string
+}
+
 // getTagOptionValue gets a tag option value. For example, given a tag "type:keyword", getTagOptionValue("type")
 // returns "keyword".
 func getTagOptionValue(structField reflect.StructField, tagKey string, optionKey string) string {
+	for _, opt := range getTagOptions(structField, tagKey) {
+		if opt.key == optionKey {
+			return opt.val
+		}
+	}
+	return ""
+}
+
+// getTagOptions parses all options of a tag, in the order they are given. For example, given a tag
+// "type:keyword, copy_to:a;b", getTagOptions returns options "type" and "copy_to" with values "keyword" and "a;b".
+// Flags, options without a value such as "id", are returned with an empty value.
+func getTagOptions(structField reflect.StructField, tagKey string) []tagOption {
 	const tagOptionSep = ","
 	const keyValSep = ":"
-	if tag := structField.Tag.Get(tagKey); tag != "" {
-		// Support values that contain semicolons, e.g. copy_to:all_test;another_text
-		segments := strings.Split(tag, tagOptionSep)
-		var (
-			currentKey string
-			currentVal string
-		)
-		done := func() (string, bool) {
-			if strings.TrimSpace(currentKey) == optionKey {
-				return strings.TrimSpace(currentVal), true
-			}
-			return "", false
-		}
-		for i, seg := range segments {
-			seg = strings.TrimSpace(seg)
-			if idx := strings.Index(seg, keyValSep); idx >= 0 {
-				// Start of a new key:value
-				if currentKey != "" {
-					if val, ok := done(); ok {
-						return val
-					}
-				}
-				currentKey = strings.TrimSpace(seg[:idx])
-				currentVal = strings.TrimSpace(seg[idx+1:])
-			} else {
-				// Continuation for previous value
-				if currentKey != "" {
-					if currentVal != "" {
-						currentVal += tagOptionSep
-					}
-					currentVal += seg
-				}
-			}
-			// Final segment: check and return if this was the target key
-			if i == len(segments)-1 {
-				if val, ok := done(); ok {
-					return val
-				}
+	tag := structField.Tag.Get(tagKey)
+	if tag == "" {
+		return nil
+	}
+
+	// Support values that contain semicolons, e.g. copy_to:all_test;another_text
+	var (
+		options []tagOption
+		current *tagOption
+	)
+	for _, seg := range strings.Split(tag, tagOptionSep) {
+		seg = strings.TrimSpace(seg)
+		if idx := strings.Index(seg, keyValSep); idx >= 0 {
+			// Start of a new key:value
+			options = append(options, tagOption{
+				key: strings.TrimSpace(seg[:idx]),
+				val: strings.TrimSpace(seg[idx+1:]),
+			})
+			current = &options[len(options)-1]
+		} else if tagFlags[seg] {
+			options = append(options, tagOption{key: seg})
+			current = nil
+		} else if current != nil && current.key != "" {
+			// Continuation for previous value
+			if current.val != "" {
+				current.val += tagOptionSep
 			}
+			current.val += seg
 		}
 	}
-	return ""
+	return options
 }
 
 // parseCustomPropertyValue parses a string like "min_chars=2;foo=bar" into a map like
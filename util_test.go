@@ -58,3 +58,50 @@ func Test_parseCustomPropertyValue(t *testing.T) {
 		"foo":       "bar",
 	}))
 }
+
+func Test_getTagOptions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type foo struct {
+		a string `opensearch:"type:text, copy_to:a;b, fields.raw:type=keyword;ignore_above=256"`
+		b string `opensearch:"format:a,b,type:date"`
+		c string `opensearch:"type:keyword,id"`
+	}
+
+	v := reflect.TypeOf(foo{})
+	g.Expect(getTagOptions(v.Field(0), "opensearch")).To(gomega.Equal([]tagOption{
+		{key: "type", val: "text"},
+		{key: "copy_to", val: "a;b"},
+		{key: "fields.raw", val: "type=keyword;ignore_above=256"},
+	}))
+	g.Expect(getTagOptions(v.Field(1), "opensearch")).To(gomega.Equal([]tagOption{
+		{key: "format", val: "a,b"},
+		{key: "type", val: "date"},
+	}))
+	g.Expect(getTagOptions(v.Field(2), "opensearch")).To(gomega.Equal([]tagOption{
+		{key: "type", val: "keyword"},
+		{key: "id", val: ""},
+	}))
+	g.Expect(getTagOptions(v.Field(1), "json")).To(gomega.BeNil())
+}
+
+func Test_parseFieldTags(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	type foo struct {
+		a string `opensearch:"type:text, analyzer:standard, type:keyword, fields.raw:type=keyword, copy_to:a;b, fields.en:"`
+		b string `opensearch:"id"`
+	}
+
+	tags := parseFieldTags(reflect.TypeOf(foo{}).Field(0))
+	g.Expect(tags.fieldType).To(gomega.Equal("text"))
+	g.Expect(tags.analyzer).To(gomega.Equal("standard"))
+	g.Expect(tags.copyTo).To(gomega.Equal("a;b"))
+	g.Expect(tags.format).To(gomega.Equal(""))
+	g.Expect(tags.fields).To(gomega.Equal([]tagOption{
+		{key: "raw", val: "type=keyword"},
+		{key: "en", val: ""},
+	}))
+	g.Expect(tags.id).To(gomega.BeFalse())
+	g.Expect(parseFieldTags(reflect.TypeOf(foo{}).Field(1)).id).To(gomega.BeTrue())
+}